@@ -5,6 +5,7 @@
 package XApp
 
 import (
+	"os"
 	"sync"
 	"testing"
 	"time"
@@ -13,6 +14,20 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// TestMain 在临时目录中运行测试，避免默认路径的本地配置写入至代码目录。
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "XApp")
+	if err != nil {
+		panic(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		panic(err)
+	}
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
 type MockApp struct {
 	started bool
 	stopped bool
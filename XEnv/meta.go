@@ -317,6 +317,15 @@ func Secret() string {
 	return secret
 }
 
+// init 向 XPrefs 注册远程配置的地址及缓存目录，使 XPrefs.Remote 从 Remote 拉取配置并缓存至 LocalPath；
+// 同时注册叠加配置的名称，使资产配置依次叠加运行模式及渠道的配置。
+func init() {
+	XPrefs.SetRemote(Remote, LocalPath)
+	XPrefs.SetProfile(func(get func(key, defval string) string) []string {
+		return []string{get(PrefsMode, PrefsModeDefault), get(PrefsChannel, PrefsChannelDefault)}
+	})
+}
+
 // Remote 返回远程配置文件路径。
 // 返回值：
 //   - string：远程配置文件路径
@@ -85,7 +85,7 @@ func (apt *fileAdapter) init(prefs XPrefs.IBase) LevelType {
 	if prefs == nil {
 		return LevelUndefined
 	}
-	tmpLevel := prefs.GetString(prefsFileLevel, prefsFileLevelDefault)
+	tmpLevel := prefsLevel(prefs, prefsFileLevel, prefsFileLevelDefault)
 	switch tmpLevel {
 	case LevelDebugStr:
 		apt.level = LevelDebug
@@ -6,6 +6,7 @@ package XLog
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"os/signal"
 	"strings"
@@ -70,6 +71,15 @@ const (
 	LevelDebugStr = "Debug"
 )
 
+// prefsLevel 读取日志级别的配置。
+// 日志级别仅支持名称（如 Debug），非字符串类型的配置使用默认值，不按照 XPrefs 的类型转换规则转换为字符串。
+func prefsLevel(prefs XPrefs.IBase, key, defval string) string {
+	if level, ok := prefs.Get(key).(string); ok {
+		return level
+	}
+	return defval
+}
+
 // levelMax 是可以输出的最大日志级别。
 var levelMax LevelType
 
@@ -109,7 +119,29 @@ type logAdapter interface {
 	close()
 }
 
-func init() { setup(XPrefs.Asset()) }
+func init() {
+	levels := []any{LevelEmergencyStr, LevelAlertStr, LevelCriticalStr, LevelErrorStr, LevelWarnStr, LevelNoticeStr, LevelInfoStr, LevelDebugStr}
+	count := []float64{0, math.Inf(1)}
+	XPrefs.RegisterSchema("Log/", map[string]*XPrefs.Schema{
+		"Log/Std": {Type: XPrefs.TypeObject, Fields: map[string]*XPrefs.Schema{
+			stdPrefsLevel: {Type: XPrefs.TypeString, Enum: levels},
+			stdPrefsColor: {Type: XPrefs.TypeBoolean},
+		}},
+		"Log/File": {Type: XPrefs.TypeObject, Fields: map[string]*XPrefs.Schema{
+			prefsFileLevel:   {Type: XPrefs.TypeString, Enum: levels},
+			prefsFileRotate:  {Type: XPrefs.TypeBoolean},
+			prefsFileDaily:   {Type: XPrefs.TypeBoolean},
+			prefsFileMaxDay:  {Type: XPrefs.TypeInteger, Range: count},
+			prefsFileHourly:  {Type: XPrefs.TypeBoolean},
+			prefsFileMaxHour: {Type: XPrefs.TypeInteger, Range: count},
+			prefsFilePath:    {Type: XPrefs.TypeString},
+			prefsFileMaxFile: {Type: XPrefs.TypeInteger, Range: count},
+			prefsFileMaxLine: {Type: XPrefs.TypeInteger, Range: count},
+			prefsFileMaxSize: {Type: XPrefs.TypeInteger, Range: count},
+		}},
+	})
+	setup(XPrefs.Asset())
+}
 
 // setup 初始化日志系统。
 // 输入配置信息，根据配置设置日志系统的运行参数。
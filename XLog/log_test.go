@@ -376,3 +376,24 @@ func TestLogWithTag(t *testing.T) {
 
 	Close()
 }
+
+// 测试日志配置的校验规则.
+func TestSchema(t *testing.T) {
+	prefs := XPrefs.New()
+	prefs.Set("Log/Std", XPrefs.New().Set(stdPrefsLevel, LevelInfoStr).Set(stdPrefsColor, true))
+	prefs.Set("Log/File", XPrefs.New().Set(prefsFileLevel, LevelDebugStr).Set(prefsFileMaxFile, 10))
+	if err := XPrefs.Validate(prefs); err != nil {
+		t.Errorf("Validate() = %v; want nil", err)
+	}
+
+	prefs = XPrefs.New()
+	prefs.Set("Log/Std", XPrefs.New().Set(stdPrefsLevel, "Warning"))
+	prefs.Set("Log/File", XPrefs.New().Set(prefsFileMaxFile, -1).Set("Rotata", false))
+	prefs.Set("Log/Fiel", XPrefs.New())
+	err := XPrefs.Validate(prefs)
+	for _, msg := range []string{"Log/Std.Level", "Log/File.MaxFile", "Log/File.Rotata: unknown key", "Log/Fiel: unknown key"} {
+		if err == nil || !bytes.Contains([]byte(err.Error()), []byte(msg)) {
+			t.Errorf("Validate() = %v; want error contains %v", err, msg)
+		}
+	}
+}
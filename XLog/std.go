@@ -66,7 +66,7 @@ func (apt *stdAdapter) init(prefs XPrefs.IBase) LevelType {
 	if prefs == nil {
 		return LevelUndefined
 	}
-	tmpLevel := prefs.GetString(stdPrefsLevel, stdPrefsLevelDefault)
+	tmpLevel := prefsLevel(prefs, stdPrefsLevel, stdPrefsLevelDefault)
 	switch tmpLevel {
 	case LevelDebugStr:
 		apt.level = LevelDebug
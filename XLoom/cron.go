@@ -0,0 +1,370 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/eframework-org/GO.UTIL/XLog"
+)
+
+const (
+	cronCheck = 1000    // 计划任务的最长检查间隔（毫秒），用于及时感知系统时钟的跳变
+	cronDays  = 366 * 5 // 计算下一次触发时间时最多向后查找的天数
+)
+
+// cronField 定义了表达式字段的取值范围及名称。
+type cronField struct {
+	min, max int            // 取值范围
+	names    map[string]int // 取值名称，如 JAN、MON 等
+}
+
+var (
+	cronSecond = cronField{min: 0, max: 59}
+	cronMinute = cronField{min: 0, max: 59}
+	cronHour   = cronField{min: 0, max: 23}
+	cronDom    = cronField{min: 1, max: 31}
+	cronMonth  = cronField{min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}}
+	cronDow = cronField{min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}}
+	cronDescriptors = map[string]string{
+		"@yearly":   "0 0 0 1 1 *",
+		"@annually": "0 0 0 1 1 *",
+		"@monthly":  "0 0 0 1 * *",
+		"@weekly":   "0 0 0 * * 0",
+		"@daily":    "0 0 0 * * *",
+		"@midnight": "0 0 0 * * *",
+		"@hourly":   "0 0 * * * *",
+	}
+)
+
+// Cron 定义了解析后的计划表达式。
+type Cron struct {
+	spec     string         // 原始表达式
+	second   uint64         // 秒的取值集合
+	minute   uint64         // 分的取值集合
+	hour     uint64         // 时的取值集合
+	dom      uint64         // 日的取值集合
+	month    uint64         // 月的取值集合
+	dow      uint64         // 星期的取值集合
+	domStar  bool           // 日是否为 * 或 ?
+	dowStar  bool           // 星期是否为 * 或 ?
+	location *time.Location // 计算所使用的时区
+}
+
+// ParseCron 解析计划表达式。
+// spec 为计划表达式，支持以下格式：
+//   - 5 个字段：分 时 日 月 星期，如 "0 4 * * *" 表示每天 04:00
+//   - 6 个字段：秒 分 时 日 月 星期，如 "*/10 * * * * *" 表示每 10 秒
+//   - 预定义：@yearly、@annually、@monthly、@weekly、@daily、@midnight、@hourly
+//
+// 字段支持 *、?、列表（1,3）、范围（1-5）、步长（*/15、10-40/10）及月份和星期的英文缩写，星期的 0 和 7 均表示周日。
+// 日和星期同时被限定时，满足任一条件即触发。
+// 表达式可以使用 "CRON_TZ=时区 " 或 "TZ=时区 " 前缀指定时区，如 "CRON_TZ=Asia/Shanghai 0 4 * * *"，默认使用 time.Local。
+// 返回解析后的计划表达式及解析过程中发生的错误。
+func ParseCron(spec string) (*Cron, error) {
+	c := &Cron{spec: spec, location: time.Local}
+	expr := strings.TrimSpace(spec)
+	if strings.HasPrefix(expr, "CRON_TZ=") || strings.HasPrefix(expr, "TZ=") {
+		zone, rest, _ := strings.Cut(expr, " ")
+		_, name, _ := strings.Cut(zone, "=")
+		location, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %v", name, err)
+		}
+		c.location = location
+		expr = strings.TrimSpace(rest)
+	}
+	if strings.HasPrefix(expr, "@") {
+		descriptor, ok := cronDescriptors[strings.ToLower(expr)]
+		if !ok {
+			return nil, fmt.Errorf("unknown descriptor %q", expr)
+		}
+		expr = descriptor
+	}
+
+	fields := strings.Fields(expr)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("expected 5 or 6 fields, found %v in %q", len(fields), expr)
+	}
+
+	var err error
+	if c.second, _, err = parseCronField(fields[0], cronSecond); err != nil {
+		return nil, fmt.Errorf("invalid second %q: %v", fields[0], err)
+	}
+	if c.minute, _, err = parseCronField(fields[1], cronMinute); err != nil {
+		return nil, fmt.Errorf("invalid minute %q: %v", fields[1], err)
+	}
+	if c.hour, _, err = parseCronField(fields[2], cronHour); err != nil {
+		return nil, fmt.Errorf("invalid hour %q: %v", fields[2], err)
+	}
+	if c.dom, c.domStar, err = parseCronField(fields[3], cronDom); err != nil {
+		return nil, fmt.Errorf("invalid day of month %q: %v", fields[3], err)
+	}
+	if c.month, _, err = parseCronField(fields[4], cronMonth); err != nil {
+		return nil, fmt.Errorf("invalid month %q: %v", fields[4], err)
+	}
+	if c.dow, c.dowStar, err = parseCronField(fields[5], cronDow); err != nil {
+		return nil, fmt.Errorf("invalid day of week %q: %v", fields[5], err)
+	}
+	if c.dow&(1<<7) != 0 { // 7 与 0 均表示周日
+		c.dow = c.dow&^(1<<7) | 1
+	}
+	return c, nil
+}
+
+// parseCronField 解析表达式的单个字段，返回取值集合及是否为 * 或 ?。
+func parseCronField(expr string, field cronField) (uint64, bool, error) {
+	var set uint64
+	star := expr == "*" || expr == "?"
+	for _, part := range strings.Split(expr, ",") {
+		rng, stepExpr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepExpr)
+			if err != nil || n <= 0 {
+				return 0, false, fmt.Errorf("invalid step %q", stepExpr)
+			}
+			step = n
+		}
+
+		var lo, hi int
+		switch {
+		case rng == "*" || rng == "?":
+			lo, hi = field.min, field.max
+		default:
+			loExpr, hiExpr, hasRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = parseCronValue(loExpr, field); err != nil {
+				return 0, false, err
+			}
+			hi = lo
+			if hasRange {
+				if hi, err = parseCronValue(hiExpr, field); err != nil {
+					return 0, false, err
+				}
+			} else if hasStep {
+				hi = field.max
+			}
+			if lo > hi {
+				return 0, false, fmt.Errorf("invalid range %q", rng)
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, star, nil
+}
+
+// parseCronValue 解析字段中的单个取值，支持数字及英文缩写。
+func parseCronValue(expr string, field cronField) (int, error) {
+	if v, ok := field.names[strings.ToUpper(expr)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(expr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", expr)
+	}
+	if v < field.min || v > field.max {
+		return 0, fmt.Errorf("value %v out of range [%v, %v]", v, field.min, field.max)
+	}
+	return v, nil
+}
+
+// String 返回原始的计划表达式。
+func (c *Cron) String() string { return c.spec }
+
+// Location 返回计划表达式计算所使用的时区。
+func (c *Cron) Location() *time.Location { return c.location }
+
+// matchDay 判断指定日期是否满足日、月及星期的条件。
+func (c *Cron) matchDay(t time.Time) bool {
+	if c.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// date 返回指定时区中的时间，并返回其是否因夏令时开始而不存在。
+// 不存在的时间将顺延至跳变之后，如跳过 02:00-03:00 时 02:30 顺延为 03:30。
+func (c *Cron) date(year int, month time.Month, day, hour, minute, second int) (time.Time, bool) {
+	t := time.Date(year, month, day, hour, minute, second, 0, c.location)
+	want := time.Date(year, month, day, hour, minute, second, 0, time.UTC)
+	got := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	if got.Equal(want) {
+		return t, false
+	}
+	if got.Before(want) {
+		t = t.Add(want.Sub(got))
+	}
+	return t, true
+}
+
+// Next 计算指定时间之后的下一次触发时间，结果使用计划表达式的时区。
+// 夏令时开始时被跳过的触发时间将顺延至跳变之后，夏令时结束时重复的时段仅触发一次。
+// 若 5 年内不存在满足条件的时间，如 "0 0 30 2 *"，则返回零值。
+func (c *Cron) Next(from time.Time) time.Time {
+	from = from.In(c.location)
+	year, month, day := from.Date()
+	var best time.Time
+	for i := range cronDays {
+		date := time.Date(year, month, day+i, 12, 0, 0, 0, c.location) // 使用正午避免零点不存在的时区
+		if !c.matchDay(date) {
+			continue
+		}
+		y, m, d := date.Date()
+		for h := range 24 {
+			if c.hour&(1<<h) == 0 {
+				continue
+			}
+			if end, _ := c.date(y, m, d, h, 59, 59); !end.After(from) {
+				continue
+			}
+			for mi := range 60 {
+				if c.minute&(1<<mi) == 0 {
+					continue
+				}
+				if end, _ := c.date(y, m, d, h, mi, 59); !end.After(from) {
+					continue
+				}
+				for s := range 60 {
+					if c.second&(1<<s) == 0 {
+						continue
+					}
+					t, shifted := c.date(y, m, d, h, mi, s)
+					if !t.After(from) {
+						continue
+					}
+					if best.IsZero() || t.Before(best) {
+						best = t
+					}
+					// 未顺延的时间即为当天之后最早的触发时间，顺延的时间需与之后的时间比较。
+					if !shifted {
+						return best
+					}
+				}
+			}
+		}
+		if !best.IsZero() {
+			return best
+		}
+	}
+	return best
+}
+
+// cronJob 定义了基于定时器的计划任务。
+type cronJob struct {
+	cron     *Cron        // 计划表达式
+	callback func()       // 计划任务的回调函数
+	next     atomic.Int64 // 下一次触发时间（纳秒），0 表示不再触发
+}
+
+// fire 检查系统时间是否到达下一次触发时间，到达时执行回调。
+func (job *cronJob) fire() {
+	if job.check(time.Now()) {
+		job.callback()
+	}
+}
+
+// check 判断指定时间是否到达下一次触发时间，到达时根据该时间计算下一次触发时间。
+// 系统时钟向前跳变跨越多个触发时间时仅触发一次，向后回拨时保留原有的触发时间，不会重复触发已触发的时间。
+func (job *cronJob) check(now time.Time) bool {
+	next := job.next.Load()
+	if next == 0 || now.UnixNano() < next {
+		return false
+	}
+	job.schedule(now)
+	return true
+}
+
+// schedule 计算指定时间之后的下一次触发时间。
+func (job *cronJob) schedule(from time.Time) {
+	if next := job.cron.Next(from); next.IsZero() {
+		job.next.Store(0)
+		XLog.Warn("XLoom.Schedule: no further activation of %q.", job.cron.spec)
+	} else {
+		job.next.Store(next.UnixNano())
+	}
+}
+
+// delay 返回距离下一次触发的剩余时间（毫秒），最长不超过 cronCheck，以便及时感知系统时钟的跳变。
+func (job *cronJob) delay() int {
+	next := job.next.Load()
+	if next == 0 {
+		return cronCheck
+	}
+	remain := time.Until(time.Unix(0, next))
+	if remain <= 0 {
+		return 0
+	}
+	return min(int((remain+time.Millisecond-1)/time.Millisecond), cronCheck)
+}
+
+// Schedule 在默认线程池中设置一个计划任务，参见 Pool.Schedule。
+func Schedule(spec string, callback func(), loomID ...int) int {
+	return defaultPool.Schedule(spec, callback, loomID...)
+}
+
+// Schedule 设置一个计划任务，按照计划表达式周期性地在线程中执行。
+// spec 为计划表达式，格式参考 ParseCron，如 "0 4 * * *"、"CRON_TZ=Asia/Shanghai 0 0 * * MON"。
+// callback 为要执行的回调函数。
+// loomID 为可选的目标线程 ID，如果未指定，在当前线程中执行。
+// 计划任务基于线程的定时器运行，每隔至多 1 秒检查一次系统时间，因此能够正确处理夏令时及系统时钟的跳变。
+// 返回定时器 ID，可以使用 ClearSchedule 取消，如果参数无效则返回 -1。
+func (p *Pool) Schedule(spec string, callback func(), loomID ...int) int {
+	if callback == nil {
+		XLog.Critical("XLoom.Schedule: callback can not be nil.")
+		return -1
+	}
+	cron, err := ParseCron(spec)
+	if err != nil {
+		XLog.Critical("XLoom.Schedule: parse spec of %q failed: %v.", spec, err)
+		return -1
+	}
+	next := cron.Next(time.Now())
+	if next.IsZero() {
+		XLog.Critical("XLoom.Schedule: spec of %q will never be activated.", spec)
+		return -1
+	}
+	job := &cronJob{cron: cron, callback: callback}
+	job.next.Store(next.UnixNano())
+
+	timer, l := p.newTimer("Schedule", job.fire, job.delay(), true, loomID)
+	if timer == nil {
+		return -1
+	}
+	timer.pc = funcPC(callback) // 报告用户的回调函数，而非包装的 fire
+	timer.period.Store(cronCheck)
+	timer.delay = job.delay
+	id := timer.id // 添加后的定时器可能被立即触发并回收
+	l.timers.add(timer)
+	return id
+}
+
+// ClearSchedule 取消默认线程池中的一个计划任务，参见 Pool.ClearSchedule。
+func ClearSchedule(id int, loomID ...int) { defaultPool.ClearSchedule(id, loomID...) }
+
+// ClearSchedule 取消一个计划任务。
+// id 为要取消的定时器 ID。
+// loomID 为可选的目标线程 ID，如果未指定，在当前线程中执行。
+func (p *Pool) ClearSchedule(id int, loomID ...int) { p.ClearTimeout(id, loomID...) }
@@ -0,0 +1,193 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/eframework-org/GO.UTIL/XPrefs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCron(t *testing.T) {
+	t.Run("Parse", func(t *testing.T) {
+		valids := []string{
+			"* * * * *",
+			"*/10 * * * * *",
+			"0 4 * * *",
+			"0,30 9-18 * * MON-FRI",
+			"10-40/10 * ? JAN,jul *",
+			"0 0 * * 7",
+			"@daily",
+			"@HOURLY",
+			"CRON_TZ=Asia/Shanghai 0 4 * * *",
+			"TZ=UTC @weekly",
+		}
+		for _, spec := range valids {
+			_, err := ParseCron(spec)
+			assert.NoError(t, err, "表达式 %q 应当解析成功", spec)
+		}
+
+		invalids := []string{
+			"",
+			"* * * *",
+			"* * * * * * *",
+			"60 * * * *",
+			"* 24 * * *",
+			"* * 0 * *",
+			"* * * 13 *",
+			"* * * * 8",
+			"*/0 * * * *",
+			"5-1 * * * *",
+			"* * * FOO *",
+			"@never",
+			"CRON_TZ=Mars/Base * * * * *",
+		}
+		for _, spec := range invalids {
+			_, err := ParseCron(spec)
+			assert.Error(t, err, "表达式 %q 应当解析失败", spec)
+		}
+	})
+
+	t.Run("Next", func(t *testing.T) {
+		utc := time.UTC
+		cases := []struct {
+			spec     string
+			from     time.Time
+			expected time.Time
+		}{
+			{"TZ=UTC */15 * * * *", time.Date(2025, 1, 1, 10, 7, 30, 0, utc), time.Date(2025, 1, 1, 10, 15, 0, 0, utc)},
+			{"TZ=UTC 0 0 * * *", time.Date(2025, 1, 1, 0, 0, 0, 0, utc), time.Date(2025, 1, 2, 0, 0, 0, 0, utc)},
+			{"TZ=UTC */10 * * * * *", time.Date(2025, 1, 1, 23, 59, 55, 0, utc), time.Date(2025, 1, 2, 0, 0, 0, 0, utc)},
+			{"TZ=UTC 0 9 * * MON", time.Date(2025, 1, 1, 0, 0, 0, 0, utc), time.Date(2025, 1, 6, 9, 0, 0, 0, utc)},
+			{"TZ=UTC 0 0 * * 7", time.Date(2025, 1, 1, 0, 0, 0, 0, utc), time.Date(2025, 1, 5, 0, 0, 0, 0, utc)},
+			{"TZ=UTC 0 0 13 * FRI", time.Date(2025, 1, 1, 0, 0, 0, 0, utc), time.Date(2025, 1, 3, 0, 0, 0, 0, utc)}, // 日和星期满足任一即可
+			{"TZ=UTC 0 0 29 2 *", time.Date(2025, 1, 1, 0, 0, 0, 0, utc), time.Date(2028, 2, 29, 0, 0, 0, 0, utc)},
+			{"TZ=UTC @monthly", time.Date(2025, 1, 31, 12, 0, 0, 0, utc), time.Date(2025, 2, 1, 0, 0, 0, 0, utc)},
+			{"CRON_TZ=Asia/Shanghai 0 4 * * *", time.Date(2025, 1, 1, 0, 0, 0, 0, utc), time.Date(2025, 1, 1, 20, 0, 0, 0, utc)},
+		}
+		for _, c := range cases {
+			cron, err := ParseCron(c.spec)
+			if assert.NoError(t, err) {
+				assert.True(t, c.expected.Equal(cron.Next(c.from)), "表达式 %q 在 %v 之后的触发时间应当为 %v，实际为 %v",
+					c.spec, c.from, c.expected, cron.Next(c.from))
+			}
+		}
+
+		cron, _ := ParseCron("TZ=UTC 0 0 30 2 *")
+		assert.True(t, cron.Next(time.Now()).IsZero(), "不存在的日期应当返回零值")
+	})
+
+	t.Run("DST", func(t *testing.T) {
+		ny, err := time.LoadLocation("America/New_York")
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		// 2025-03-09 02:00 夏令时开始，02:00-03:00 不存在
+		cron, _ := ParseCron("CRON_TZ=America/New_York 30 2 * * *")
+		next := cron.Next(time.Date(2025, 3, 9, 0, 0, 0, 0, ny))
+		assert.Equal(t, time.Date(2025, 3, 9, 3, 30, 0, 0, ny), next, "被跳过的触发时间应当顺延至跳变之后")
+		next = cron.Next(next)
+		assert.Equal(t, time.Date(2025, 3, 10, 2, 30, 0, 0, ny), next, "顺延后次日应当恢复正常")
+
+		cron, _ = ParseCron("CRON_TZ=America/New_York 30 2,3 * * *")
+		next = cron.Next(time.Date(2025, 3, 9, 0, 0, 0, 0, ny))
+		assert.Equal(t, time.Date(2025, 3, 9, 3, 30, 0, 0, ny), next)
+		next = cron.Next(next)
+		assert.Equal(t, time.Date(2025, 3, 10, 2, 30, 0, 0, ny), next, "顺延的触发时间与正常的触发时间重合时应当仅触发一次")
+
+		cron, _ = ParseCron("CRON_TZ=America/New_York 0 * * * *")
+		hours := []int{}
+		for next = time.Date(2025, 3, 9, 0, 30, 0, 0, ny); next.Before(time.Date(2025, 3, 9, 5, 0, 0, 0, ny)); {
+			next = cron.Next(next)
+			hours = append(hours, next.Hour())
+		}
+		assert.Equal(t, []int{1, 3, 4, 5}, hours, "夏令时开始时每小时任务应当跳过不存在的时段")
+
+		// 2025-11-02 02:00 夏令时结束，01:00-02:00 重复
+		cron, _ = ParseCron("CRON_TZ=America/New_York 30 1 * * *")
+		first := cron.Next(time.Date(2025, 11, 2, 0, 0, 0, 0, ny))
+		assert.Equal(t, 1, first.Hour())
+		next = cron.Next(first)
+		assert.Equal(t, 3, next.Day(), "重复的时段应当仅触发一次")
+		assert.Equal(t, 1, next.Hour())
+	})
+
+	t.Run("Clock", func(t *testing.T) {
+		cron, _ := ParseCron("TZ=UTC 0 * * * *")
+		base := time.Date(2025, 1, 1, 10, 30, 0, 0, time.UTC)
+		job := &cronJob{cron: cron}
+		job.schedule(base)
+
+		assert.False(t, job.check(base.Add(time.Minute*10)), "未到达触发时间时不应当触发")
+		assert.True(t, job.check(base.Add(time.Hour*5)), "时钟向前跳变后应当立即触发")
+		assert.False(t, job.check(base.Add(time.Hour*5+time.Second)), "跳变跨越的多个触发时间应当仅触发一次")
+		assert.Equal(t, time.Date(2025, 1, 1, 16, 0, 0, 0, time.UTC).UnixNano(), job.next.Load(), "下一次触发时间应当根据跳变后的时间计算")
+
+		assert.False(t, job.check(base), "时钟回拨后不应当重复触发已触发的时间")
+		assert.True(t, job.check(time.Date(2025, 1, 1, 16, 0, 0, 0, time.UTC)), "时钟回拨后应当保留原有的触发时间")
+	})
+
+	t.Run("Schedule", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 1).Set(prefsStep, 10).Set(prefsQueue, 1000))
+		defer setup(XPrefs.Asset())
+
+		var count atomic.Int32
+		id := Schedule("* * * * * *", func() { count.Add(1) }, 0)
+		assert.Greater(t, id, 0, "计划任务应当设置成功")
+		time.Sleep(time.Millisecond * 2500)
+		ClearSchedule(id, 0)
+		fired := count.Load()
+		assert.GreaterOrEqual(t, fired, int32(2), "每秒触发的计划任务应当至少触发 2 次")
+		assert.LessOrEqual(t, fired, int32(3), "每秒触发的计划任务应当至多触发 3 次")
+		time.Sleep(time.Millisecond * 1200)
+		assert.Equal(t, fired, count.Load(), "取消后计划任务不应当再次触发")
+
+		assert.Equal(t, -1, Schedule("* * * * * *", nil, 0), "空的回调函数应当设置失败")
+		assert.Equal(t, -1, Schedule("invalid", func() {}, 0), "无效的表达式应当设置失败")
+		assert.Equal(t, -1, Schedule("0 0 30 2 *", func() {}, 0), "永不触发的表达式应当设置失败")
+		assert.Equal(t, -1, Schedule("* * * * *", func() {}, 999), "无效的线程 ID 应当设置失败")
+	})
+
+	t.Run("Report", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 1).Set(prefsStep, 10).Set(prefsQueue, 1000).Set(prefsBudget, 50))
+		defer setup(XPrefs.Asset())
+
+		reports := make(chan SlowReport, 4)
+		unsub := OnSlow(func(report SlowReport) {
+			select {
+			case reports <- report:
+			default:
+			}
+		})
+		defer unsub()
+
+		id := Schedule("* * * * * *", func() { time.Sleep(time.Millisecond * 60) }, 0)
+		defer ClearSchedule(id, 0)
+
+		found := false
+		for _, info := range Timers(0) {
+			if info.ID == id {
+				found = true
+				assert.Contains(t, info.Func, "TestCron", "定时器信息应当包含用户的回调函数名称")
+				assert.NotContains(t, info.Func, "fire", "定时器信息不应当包含计划任务的包装函数")
+			}
+		}
+		assert.True(t, found, "应当返回计划任务的定时器信息")
+
+		select {
+		case report := <-reports:
+			assert.Equal(t, "timer", report.Kind)
+			assert.Contains(t, report.Func, "TestCron", "慢执行报告应当包含用户的回调函数名称")
+			assert.NotContains(t, report.Func, "fire", "慢执行报告不应当包含计划任务的包装函数")
+		case <-time.After(time.Millisecond * 2500):
+			t.Fatal("计划任务的慢执行未被报告")
+		}
+	})
+}
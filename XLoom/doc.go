@@ -8,8 +8,19 @@ XLoom 提供了一个轻量级的任务调度系统，用于管理异步任务
 功能特性
 
   - 异步任务：支持执行和异常恢复异步任务
+  - 结构化并发：支持任务组的错误传播、上下文取消、并发限制、有限重试及线程亲和
   - 线程管理：支持任务管理、线程暂停/恢复控制、指标监控（FPS/QPS）
-  - 定时器管理：支持设置/取消超时和间歇调用
+  - 键值调度：支持按键值将任务绑定至固定线程，并保证同一键值的任务顺序执行
+  - 任务优先级：支持高/普通/低三个优先级通道，并提供饥饿保护
+  - 帧回调：支持注册线程的帧更新及帧后更新回调
+  - 动态扩缩容：支持运行时调整线程池大小，并迁移退役线程的任务、定时器及帧回调
+  - 优雅退出：支持退出时在限定时间内排空剩余任务，并报告丢弃的任务及定时器
+  - 慢执行检测：支持检测超出预算的任务、定时器回调及帧更新，并输出函数名称及线程堆栈
+  - 阻塞任务：支持在有界的工作池中执行阻塞 I/O，并将结果投递回发起调用的线程
+  - 独立线程池：支持创建拥有独立配置、定时器、指标及生命周期的线程池
+  - 类型化信箱：支持绑定至线程的类型化信箱，按帧批量投递消息，避免为每条消息分配闭包
+  - 系统线程绑定：支持将线程锁定至独立的系统线程，并在 Linux 上绑定 CPU 集合
+  - 定时器管理：支持设置/取消超时和间歇调用，支持定时器句柄的查询、暂停及重置，支持时区及夏令时的计划任务
 
 使用手册
 
@@ -32,6 +43,18 @@ XLoom 提供了一个轻量级的任务调度系统，用于管理异步任务
 		panic("recoverable")
 	}, true) // true 表示发生异常时重试
 
+1.2 结构化并发
+
+	// 创建任务组，最多同时执行 4 个任务，失败后最多重试 3 次
+	g, ctx := XLoom.NewGroup(context.Background(), XLoom.WithLimit(4), XLoom.WithRetry(3, 100*time.Millisecond))
+
+	// 在新的 goroutine 或指定线程中执行任务，任一任务失败时取消 ctx
+	g.Go(func(ctx context.Context) error { return fetch(ctx) })
+	g.GoIn(1, func(ctx context.Context) error { return apply(ctx) })
+
+	// 等待所有任务结束，返回首个错误，panic 将转换为 PanicError
+	err := g.Wait()
+
 2. 线程管理
 
 2.1 任务调度
@@ -41,9 +64,13 @@ XLoom 提供了一个轻量级的任务调度系统，用于管理异步任务
 		fmt.Println("在线程0中执行")
 	}, 0)
 
-	// 获取当前线程 loom ID
+	// 获取当前线程 loom ID，可在任意 goroutine 中调用
 	pid := XLoom.ID()
 
+	// 通过上下文传递线程 ID
+	ctx := XLoom.NewContext(context.Background(), 0)
+	pid = XLoom.LoomFrom(ctx)
+
 	// 获取线程性能指标
 	fps := XLoom.FPS(0) // 线程0的帧率
 	qps := XLoom.QPS(0) // 线程0的处理速率
@@ -58,6 +85,106 @@ XLoom 提供了一个轻量级的任务调度系统，用于管理异步任务
 	XLoom.Pause()
 	XLoom.Resume()
 
+2.3 键值调度
+
+	// 获取键值所绑定的线程 ID
+	lid := XLoom.LoomOf(playerID)
+
+	// 在键值所绑定的线程中串行执行任务
+	XLoom.RunInKey(playerID, func() {
+		fmt.Println("在玩家所属线程中执行")
+	})
+
+2.4 任务优先级
+
+	// 以高优先级在线程0中执行任务
+	XLoom.RunInWith(func() {
+		fmt.Println("紧急的控制消息")
+	}, XLoom.WithLoom(0), XLoom.WithPriority(XLoom.PriorityHigh))
+
+2.5 帧回调
+
+	// 注册线程0的帧更新回调，delta 为距离上一帧的时间（毫秒）
+	unsub := XLoom.OnUpdate(0, func(delta int) {
+		world.Update(delta)
+	})
+
+	// 注册线程0的帧后更新回调，在定时器更新之后执行
+	unsubLate := XLoom.OnLateUpdate(0, func(delta int) {
+		world.Sync()
+	})
+
+	// 注销帧回调
+	unsub()
+	unsubLate()
+
+2.6 动态扩缩容
+
+	// 监听线程池扩缩容，用于重新平衡键值绑定的业务数据
+	unsub := XLoom.OnResize(func(oldCount, newCount int) {
+		fmt.Printf("线程数量由 %d 调整为 %d\n", oldCount, newCount)
+	})
+
+	// 调整线程池大小
+	XLoom.Resize(8)
+
+2.7 优雅退出
+
+	// 监听线程排空完成，报告执行及丢弃的任务数量
+	unsub := XLoom.OnDrain(func(report XLoom.DrainReport) {
+		fmt.Printf("线程 %d 丢弃了 %d 个任务\n", report.Loom, report.Discarded)
+	})
+
+2.8 慢执行检测
+
+	// 监听慢执行，执行耗时超出 Loom/Budget 时触发
+	unsub := XLoom.OnSlow(func(report XLoom.SlowReport) {
+		fmt.Printf("线程 %d 的 %s 执行了 %dms\n", report.Loom, report.Func, report.Elapsed)
+	})
+
+2.9 阻塞任务
+
+	// 在工作池中执行阻塞 I/O，结果回调在发起调用的线程中执行
+	XLoom.Offload(func() (*User, error) {
+		return db.LoadUser(id)
+	}, func(user *User, err error) {
+		fmt.Println(user, err)
+	})
+
+2.10 指标监控
+
+	// 将线程指标注册至自定义的注册器，指标均带有 loom 标签，如 xloom_fps{loom="0"}
+	reg := prometheus.NewRegistry()
+	XLoom.SetRegisterer(reg)
+
+2.11 独立线程池
+
+	// 创建独立的线程池，包级别的函数均在默认线程池中执行
+	pool := XLoom.New(XPrefs.New().Set("Loom/Count", 2).Set("Loom/MetricsPrefix", "mylib_loom"))
+	defer pool.Close()
+	pool.RunIn(func() {
+		pool.SetTimeout(func() { fmt.Println(pool.ID()) }, 1000)
+	}, 1)
+
+2.12 类型化信箱
+
+	// 创建绑定至线程 1 的信箱，消息在线程 1 的每一帧中批量处理
+	mb := XLoom.NewMailbox(1, func(msg *Packet) {
+		handle(msg)
+	}, XLoom.WithCapacity(4096))
+	mb.Post(packet)
+
+	// 在 select 语句中投递
+	select {
+	case mb.C() <- packet:
+	case <-ctx.Done():
+	}
+
+2.13 系统线程绑定
+
+	// 配置 Loom/LockThread 为 true 或 Loom/Affinity 为 ["0", "1"] 后，获取线程所在的系统线程 ID
+	tid := XLoom.ThreadID(0)
+
 3. 定时器
 
 3.1 超时调用
@@ -80,6 +207,30 @@ XLoom 提供了一个轻量级的任务调度系统，用于管理异步任务
 	// 取消间歇调用
 	XLoom.ClearInterval(id)
 
+3.3 定时器句柄
+
+	// 创建超时调用并返回句柄，句柄的方法可在任意 goroutine 中调用
+	tm := XLoom.NewTimeout(func() {
+		fmt.Println("技能冷却完成")
+	}, 5000, 0)
+	tm.Pause()
+	tm.Resume()
+	tm.Reset(3000)
+	fmt.Println(tm.Remaining(), tm.Stop())
+
+	// 查看线程中等待触发的定时器
+	infos := XLoom.Timers(0)
+
+3.4 计划任务
+
+	// 每天 04:00（北京时间）在线程 0 中执行
+	id := XLoom.Schedule("CRON_TZ=Asia/Shanghai 0 4 * * *", func() {
+		fmt.Println("每日重置")
+	}, 0)
+
+	// 取消计划任务
+	XLoom.ClearSchedule(id, 0)
+
 更多信息请参考模块文档。
 */
 package XLoom
@@ -0,0 +1,83 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"github.com/eframework-org/GO.UTIL/XLog"
+	"github.com/eframework-org/GO.UTIL/XTime"
+)
+
+const (
+	drainTimerFire   = "fire"   // 排空时立即触发剩余的超时调用
+	drainTimerCancel = "cancel" // 排空时取消剩余的超时调用
+)
+
+// DrainReport 定义了线程退出时的排空报告。
+type DrainReport struct {
+	Loom      int // 线程 ID
+	Executed  int // 排空期间执行的任务数量
+	Discarded int // 因超时而丢弃的任务数量
+	Fired     int // 排空期间立即触发的超时调用数量
+	Cancelled int // 排空期间取消的定时器数量，包含所有的间歇调用
+	Elapsed   int // 排空耗时（毫秒）
+}
+
+// drain 排空线程，在收到退出信号或线程池关闭时调用。
+// 排空期间线程不再接收新的任务，并在 Loom/DrainTimeout 时间内执行队列中剩余的任务，
+// 超时后剩余的任务将被丢弃；剩余的超时调用根据 Loom/DrainTimer 立即触发或取消，间歇调用总是被取消。
+func (l *loom) drain() DrainReport {
+	l.mu.Lock()
+	l.draining = true
+	l.mu.Unlock()
+
+	report := DrainReport{Loom: l.id}
+	start := XTime.GetMillisecond()
+	deadline := start + l.pool.drainTimeout
+
+	for {
+		task, _ := l.pickTask()
+		if task.fn == nil {
+			break
+		}
+		if XTime.GetMillisecond() >= deadline {
+			report.Discarded++
+			continue
+		}
+		func() {
+			defer XLog.Caught(false)
+			task.fn()
+		}()
+		report.Executed++
+	}
+
+	report.Fired, report.Cancelled = l.timers.flush(l.pool.drainTimer == drainTimerFire)
+	report.Elapsed = XTime.GetMillisecond() - start
+
+	if report.Discarded > 0 {
+		XLog.Warn("XLoom.Drain(%v): executed %v task(s), discarded %v task(s), fired %v timer(s), cancelled %v timer(s), elapsed %vms.",
+			l.id, report.Executed, report.Discarded, report.Fired, report.Cancelled, report.Elapsed)
+	} else {
+		XLog.Notice("XLoom.Drain(%v): executed %v task(s), fired %v timer(s), cancelled %v timer(s), elapsed %vms.",
+			l.id, report.Executed, report.Fired, report.Cancelled, report.Elapsed)
+	}
+
+	l.pool.drainHooks.each(func(callback func(DrainReport)) { callback(report) })
+	return report
+}
+
+// OnDrain 注册默认线程池中线程排空完成的回调，参见 Pool.OnDrain。
+func OnDrain(callback func(report DrainReport)) (unsub func()) { return defaultPool.OnDrain(callback) }
+
+// OnDrain 注册线程排空完成的回调。
+// callback 为排空回调函数，report 为线程的排空报告，在退出的线程中调用，线程收到退出信号或线程池关闭时触发。
+// 返回注销函数，可在任意 goroutine 中调用，重复调用无副作用。
+func (p *Pool) OnDrain(callback func(report DrainReport)) (unsub func()) {
+	if callback == nil {
+		XLog.Critical("XLoom.OnDrain: callback can not be nil.")
+		return func() {}
+	}
+
+	return p.drainHooks.add(callback)
+}
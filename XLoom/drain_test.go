@@ -0,0 +1,90 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/eframework-org/GO.UTIL/XPrefs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrain(t *testing.T) {
+	// shutdown 暂停线程 0 并堆积任务，随后模拟退出信号并返回排空报告
+	shutdown := func(t *testing.T, prepare func()) DrainReport {
+		reports := make(chan DrainReport, 1)
+		unsub := OnDrain(func(report DrainReport) { reports <- report })
+		defer unsub()
+
+		Pause(0)
+		time.Sleep(time.Millisecond * 50)
+		prepare()
+		getLoom(0).setupSig <- syscall.SIGTERM
+
+		select {
+		case report := <-reports:
+			return report
+		case <-time.After(time.Second * 3):
+			t.Fatal("线程排空超时")
+		}
+		return DrainReport{}
+	}
+
+	t.Run("Fire", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 1).Set(prefsStep, 10).Set(prefsQueue, 1000).
+			Set(prefsDrainTimeout, 1000).Set(prefsDrainTimer, drainTimerFire))
+
+		var executed, fired, repeated int32
+		report := shutdown(t, func() {
+			for range 5 {
+				RunIn(func() { atomic.AddInt32(&executed, 1) }, 0)
+			}
+			SetTimeout(func() { atomic.AddInt32(&fired, 1) }, 10000, 0)
+			SetInterval(func() { atomic.AddInt32(&repeated, 1) }, 10000, 0)
+			ClearTimeout(SetTimeout(func() { atomic.AddInt32(&fired, 1) }, 10000, 0), 0)
+		})
+
+		assert.Equal(t, 0, report.Loom)
+		assert.Equal(t, 5, report.Executed, "排空期间应当执行所有剩余的任务")
+		assert.Equal(t, 0, report.Discarded, "未超时的排空不应当丢弃任务")
+		assert.Equal(t, 1, report.Fired, "剩余的超时调用应当被立即触发")
+		assert.Equal(t, 1, report.Cancelled, "剩余的间歇调用应当被取消")
+		assert.Equal(t, int32(5), atomic.LoadInt32(&executed))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&fired), "已清除的超时调用不应当被触发")
+		assert.Equal(t, int32(0), atomic.LoadInt32(&repeated), "间歇调用不应当被触发")
+
+		// 排空后不再接收新的任务
+		rejected := true
+		RunIn(func() { rejected = false }, 0)
+		time.Sleep(time.Millisecond * 50)
+		assert.True(t, rejected, "排空后投递的任务应当被拒绝")
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 1).Set(prefsStep, 10).Set(prefsQueue, 1000).
+			Set(prefsDrainTimeout, 50).Set(prefsDrainTimer, drainTimerCancel))
+
+		var fired int32
+		report := shutdown(t, func() {
+			for range 5 {
+				RunIn(func() { time.Sleep(time.Millisecond * 30) }, 0)
+			}
+			SetTimeout(func() { atomic.AddInt32(&fired, 1) }, 10000, 0)
+		})
+
+		assert.Equal(t, 5, report.Executed+report.Discarded, "排空报告应当包含所有剩余的任务")
+		assert.Greater(t, report.Discarded, 0, "超时后剩余的任务应当被丢弃")
+		assert.Equal(t, 0, report.Fired, "取消模式下不应当触发超时调用")
+		assert.Equal(t, 1, report.Cancelled, "取消模式下剩余的超时调用应当被取消")
+		assert.Equal(t, int32(0), atomic.LoadInt32(&fired))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		assert.NotNil(t, OnDrain(nil), "传入空的回调函数应当返回空的注销函数")
+	})
+}
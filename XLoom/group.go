@@ -0,0 +1,231 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/eframework-org/GO.UTIL/XLog"
+)
+
+// ErrRejected 表示任务未能投递，如线程 ID 无效、线程正在排空或任务队列已满。
+var ErrRejected = errors.New("XLoom: task was rejected")
+
+// PanicError 定义了任务发生 panic 时转换的错误。
+type PanicError struct {
+	Value any    // panic 的值
+	Stack string // panic 的堆栈，由 XLog.Trace 生成
+}
+
+// Error 实现 error 接口。
+func (e *PanicError) Error() string { return fmt.Sprintf("XLoom: task panicked: %v", e.Stack) }
+
+// GroupOption 定义了任务组的可选参数。
+type GroupOption func(*groupOption)
+
+// groupOption 定义了任务组的参数。
+type groupOption struct {
+	limit   int           // 最大并发数，0 表示不限制
+	retry   int           // 失败后的最大重试次数
+	backoff time.Duration // 首次重试的等待时间，之后每次翻倍
+	pool    *Pool         // GoIn 投递的线程池，为 nil 时使用默认线程池
+}
+
+// WithLimit 设置任务组的最大并发数。
+// limit 为同时执行的最大任务数，小于等于 0 表示不限制。
+func WithLimit(limit int) GroupOption {
+	return func(option *groupOption) { option.limit = limit }
+}
+
+// WithRetry 设置任务失败后的重试策略。
+// times 为最大重试次数，backoff 为首次重试的等待时间，之后每次重试的等待时间翻倍。
+func WithRetry(times int, backoff time.Duration) GroupOption {
+	return func(option *groupOption) {
+		option.retry = times
+		option.backoff = backoff
+	}
+}
+
+// WithPool 设置 GoIn 投递任务的线程池，默认使用默认线程池。
+func WithPool(pool *Pool) GroupOption {
+	return func(option *groupOption) { option.pool = pool }
+}
+
+// Group 定义了结构化并发的任务组，用于派生一组任务并等待其全部结束。
+// 任一任务返回错误或发生 panic 时，任务组的上下文将被取消，Wait 返回首个错误。
+type Group struct {
+	ctx    context.Context         // 任务组的上下文
+	cancel context.CancelCauseFunc // 取消任务组的上下文
+	option groupOption             // 任务组的参数
+	sem    chan struct{}           // 并发信号量，不限制并发时为 nil
+	wg     sync.WaitGroup          // 等待所有任务结束
+	once   sync.Once               // 保证仅记录首个错误
+	err    error                   // 首个错误
+}
+
+// NewGroup 创建任务组。
+// ctx 为父级上下文，为 nil 时使用 context.Background。
+// options 为可选参数，如 WithLimit、WithRetry 等。
+// 返回任务组及其上下文，上下文在首个错误发生或 Wait 返回时取消。
+func NewGroup(ctx context.Context, options ...GroupOption) (*Group, context.Context) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	g := &Group{}
+	for _, option := range options {
+		if option != nil {
+			option(&g.option)
+		}
+	}
+	if g.option.limit > 0 {
+		g.sem = make(chan struct{}, g.option.limit)
+	}
+	g.ctx, g.cancel = context.WithCancelCause(ctx)
+	return g, g.ctx
+}
+
+// Go 在新的 goroutine 中执行任务。
+// fn 为要执行的任务函数，ctx 为任务组的上下文，返回的错误将取消任务组。
+// 达到最大并发数时阻塞调用方直至有任务结束，请勿在线程中等待以免阻塞帧更新。
+func (g *Group) Go(fn func(ctx context.Context) error) {
+	if fn == nil {
+		XLog.Critical("XLoom.Group.Go: fn can not be nil.")
+		return
+	}
+	g.spawn(func() error { return g.call(fn) })
+}
+
+// GoIn 在指定线程中执行任务，任务将以普通优先级投递至线程的任务队列。
+// loomID 为线程 ID，fn 为要执行的任务函数，ctx 携带线程 ID，可以通过 LoomFrom 获取，返回的错误将取消任务组。
+// 任务投递失败时返回 ErrRejected，上下文取消后尚未开始执行的任务将被跳过。
+// 达到最大并发数时阻塞调用方直至有任务结束，请勿在线程中等待以免阻塞帧更新。
+func (g *Group) GoIn(loomID int, fn func(ctx context.Context) error) {
+	if fn == nil {
+		XLog.Critical("XLoom.Group.GoIn: fn can not be nil.")
+		return
+	}
+	g.spawn(func() error { return g.callIn(loomID, fn) })
+}
+
+// Wait 等待所有任务结束并返回首个错误，返回后任务组的上下文将被取消。
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel(g.err)
+	return g.err
+}
+
+// spawn 获取并发信号量后在新的 goroutine 中执行任务，失败时按重试策略重试。
+func (g *Group) spawn(attempt func() error) {
+	if g.sem != nil {
+		select {
+		case g.sem <- struct{}{}:
+		case <-g.ctx.Done():
+			return
+		}
+	}
+	g.wg.Add(1)
+	go func() {
+		defer func() {
+			if g.sem != nil {
+				<-g.sem
+			}
+			g.wg.Done()
+		}()
+		backoff := g.option.backoff
+		for i := 0; ; i++ {
+			if g.ctx.Err() != nil {
+				return
+			}
+			err := attempt()
+			if err == nil {
+				return
+			}
+			if i >= g.option.retry || g.ctx.Err() != nil {
+				g.fail(err)
+				return
+			}
+			XLog.Warn("XLoom.Group: attempt %v of %v failed: %v, retry after %v.", i+1, g.option.retry+1, err, backoff)
+			select {
+			case <-time.After(backoff):
+			case <-g.ctx.Done():
+				return
+			}
+			backoff *= 2
+		}
+	}()
+}
+
+// fail 记录首个错误并取消任务组的上下文。
+func (g *Group) fail(err error) {
+	g.once.Do(func() {
+		g.err = err
+		g.cancel(err)
+	})
+}
+
+// call 执行任务函数，将 panic 转换为 PanicError。
+func (g *Group) call(fn func(ctx context.Context) error) error {
+	return protect(func() error { return fn(g.ctx) })
+}
+
+// protect 执行函数，将 panic 转换为 PanicError。
+func protect(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			stack, _ := XLog.Trace(2, r)
+			err = &PanicError{Value: r, Stack: stack}
+		}
+	}()
+	return fn()
+}
+
+// callIn 将任务函数投递至指定线程执行并等待其结束。
+func (g *Group) callIn(loomID int, fn func(ctx context.Context) error) error {
+	pool := g.option.pool
+	if pool == nil {
+		pool = defaultPool
+	}
+	l := pool.getLoom(loomID)
+	if l == nil {
+		XLog.Critical("XLoom.Group.GoIn: loom id of %v is out of range [0, %v).", loomID, pool.Count())
+		return ErrRejected
+	}
+
+	ctx := NewContext(g.ctx, loomID)
+	const (
+		pending = iota // 等待执行
+		running        // 正在执行
+		skipped        // 已跳过
+	)
+	var state atomic.Int32
+	done := make(chan error, 1)
+	if !runIn(func() {
+		if !state.CompareAndSwap(pending, running) {
+			return
+		}
+		if g.ctx.Err() != nil {
+			done <- nil
+			return
+		}
+		done <- protect(func() error { return fn(ctx) })
+	}, l, PriorityNormal) {
+		return ErrRejected
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-g.ctx.Done():
+		if state.CompareAndSwap(pending, skipped) {
+			return nil
+		}
+		return <-done
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/eframework-org/GO.UTIL/XPrefs"
+	"github.com/petermattis/goid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroup(t *testing.T) {
+	setup(XPrefs.New().Set(prefsCount, 2).Set(prefsStep, 10).Set(prefsQueue, 1000))
+	defer setup(XPrefs.Asset())
+
+	t.Run("Wait", func(t *testing.T) {
+		g, _ := NewGroup(context.Background())
+		var count atomic.Int32
+		for range 10 {
+			g.Go(func(ctx context.Context) error {
+				count.Add(1)
+				return nil
+			})
+		}
+		assert.NoError(t, g.Wait(), "所有任务成功时应当返回 nil")
+		assert.Equal(t, int32(10), count.Load(), "所有任务均应当被执行")
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		g, ctx := NewGroup(context.Background())
+		expected := errors.New("failed")
+		started := make(chan struct{})
+		cancelled := make(chan struct{})
+		g.Go(func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			close(cancelled)
+			return ctx.Err()
+		})
+		<-started
+		g.Go(func(ctx context.Context) error { return expected })
+
+		assert.Equal(t, expected, g.Wait(), "应当返回首个错误")
+		assert.Equal(t, expected, context.Cause(ctx), "上下文取消的原因应当为首个错误")
+		select {
+		case <-cancelled:
+		default:
+			t.Fatal("首个错误发生后应当取消其他任务")
+		}
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		g, _ := NewGroup(context.Background())
+		g.Go(func(ctx context.Context) error { panic("boom") })
+
+		err := g.Wait()
+		var perr *PanicError
+		if assert.ErrorAs(t, err, &perr, "panic 应当转换为 PanicError") {
+			assert.Equal(t, "boom", perr.Value)
+			assert.Contains(t, perr.Stack, "group_test.go", "堆栈应当包含 panic 的位置")
+		}
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		g, _ := NewGroup(context.Background(), WithLimit(2))
+		var running, peak atomic.Int32
+		for range 6 {
+			g.Go(func(ctx context.Context) error {
+				n := running.Add(1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond * 20)
+				running.Add(-1)
+				return nil
+			})
+		}
+		assert.NoError(t, g.Wait())
+		assert.Equal(t, int32(2), peak.Load(), "同时执行的任务数不应当超过最大并发数")
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		g, _ := NewGroup(context.Background(), WithRetry(2, time.Millisecond*10))
+		var attempts atomic.Int32
+		start := time.Now()
+		g.Go(func(ctx context.Context) error {
+			attempts.Add(1)
+			panic("always")
+		})
+		err := g.Wait()
+		assert.Error(t, err, "重试次数耗尽后应当返回错误")
+		assert.Equal(t, int32(3), attempts.Load(), "应当执行 1 次并重试 2 次")
+		assert.GreaterOrEqual(t, time.Since(start), time.Millisecond*30, "重试的等待时间应当逐次翻倍")
+
+		g, _ = NewGroup(context.Background(), WithRetry(3, time.Millisecond))
+		attempts.Store(0)
+		g.Go(func(ctx context.Context) error {
+			if attempts.Add(1) < 2 {
+				return errors.New("transient")
+			}
+			return nil
+		})
+		assert.NoError(t, g.Wait(), "重试成功后不应当返回错误")
+		assert.Equal(t, int32(2), attempts.Load())
+	})
+
+	t.Run("GoIn", func(t *testing.T) {
+		g, _ := NewGroup(context.Background())
+		var gid atomic.Int64
+		g.GoIn(1, func(ctx context.Context) error {
+			gid.Store(goid.Get())
+			return nil
+		})
+		assert.NoError(t, g.Wait())
+		assert.Equal(t, getLoom(1).gid.Load(), gid.Load(), "任务应当在指定的线程中执行")
+
+		g, _ = NewGroup(context.Background())
+		g.GoIn(999, func(ctx context.Context) error { return nil })
+		assert.ErrorIs(t, g.Wait(), ErrRejected, "无效的线程 ID 应当返回 ErrRejected")
+	})
+
+	t.Run("Skip", func(t *testing.T) {
+		g, _ := NewGroup(context.Background())
+		executed := atomic.Bool{}
+
+		Pause(0)
+		time.Sleep(time.Millisecond * 50)
+		g.GoIn(0, func(ctx context.Context) error {
+			executed.Store(true)
+			return nil
+		})
+		g.Go(func(ctx context.Context) error { return errors.New("failed") })
+		assert.Error(t, g.Wait(), "上下文取消后应当不再等待尚未执行的线程任务")
+		Resume(0)
+		time.Sleep(time.Millisecond * 50)
+		assert.False(t, executed.Load(), "上下文取消后尚未执行的线程任务应当被跳过")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		g, ctx := NewGroup(nil)
+		assert.NotNil(t, ctx, "父级上下文为 nil 时应当使用 context.Background")
+		g.Go(nil)
+		g.GoIn(0, nil)
+		assert.NoError(t, g.Wait(), "空的任务函数应当被忽略")
+		assert.Error(t, ctx.Err(), "Wait 返回后上下文应当被取消")
+	})
+}
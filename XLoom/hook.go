@@ -0,0 +1,57 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"sync"
+
+	"github.com/eframework-org/GO.UTIL/XLog"
+)
+
+// hookList 定义了线程安全的回调列表。
+type hookList[T any] struct {
+	mu    sync.Mutex // 回调列表互斥锁
+	iid   int64      // 回调自增标识
+	hooks []hook[T]  // 回调列表
+}
+
+// hook 定义了回调的基本结构。
+type hook[T any] struct {
+	id       int64 // 回调唯一标识
+	callback T     // 回调函数
+}
+
+// add 注册回调并返回注销函数，重复调用注销函数无副作用。
+func (hl *hookList[T]) add(callback T) func() {
+	hl.mu.Lock()
+	hl.iid++
+	id := hl.iid
+	hl.hooks = append(hl.hooks, hook[T]{id: id, callback: callback})
+	hl.mu.Unlock()
+
+	return func() {
+		hl.mu.Lock()
+		defer hl.mu.Unlock()
+		for idx, h := range hl.hooks {
+			if h.id == id {
+				hl.hooks = append(hl.hooks[:idx:idx], hl.hooks[idx+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// each 按照注册顺序调用回调，单个回调发生异常不会影响其他回调的执行。
+func (hl *hookList[T]) each(invoke func(T)) {
+	hl.mu.Lock()
+	hooks := hl.hooks
+	hl.mu.Unlock()
+	for _, h := range hooks {
+		func() {
+			defer XLog.Caught(false)
+			invoke(h.callback)
+		}()
+	}
+}
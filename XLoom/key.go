@@ -0,0 +1,166 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"fmt"
+	"hash/fnv"
+	"reflect"
+
+	"github.com/eframework-org/GO.UTIL/XLog"
+)
+
+// keyBox 定义了键值信箱，用于串行执行同一键值的任务。
+type keyBox struct {
+	pool    *Pool    // 信箱所属的线程池
+	key     any      // 信箱所属的键值
+	tasks   []func() // 待执行的任务队列
+	running bool     // 是否已投递至线程，同一时刻每个信箱至多投递一次
+}
+
+// hashKey 计算键值的哈希值。
+// 整型键值直接使用其数值，字符串及其他类型使用 FNV-1a 算法计算。
+func hashKey(key any) uint64 {
+	switch v := key.(type) {
+	case int:
+		return uint64(v)
+	case int32:
+		return uint64(v)
+	case int64:
+		return uint64(v)
+	case uint:
+		return uint64(v)
+	case uint32:
+		return uint64(v)
+	case uint64:
+		return v
+	case string:
+		hash := fnv.New64a()
+		hash.Write([]byte(v))
+		return hash.Sum64()
+	default:
+		hash := fnv.New64a()
+		hash.Write(fmt.Appendf(nil, "%v", key))
+		return hash.Sum64()
+	}
+}
+
+// jumpHash 使用 Jump Consistent Hash 算法将哈希值映射至 [0, buckets) 区间。
+// 当分桶数量变化时，仅有约 1/n 的键值会迁移至新的分桶。
+func jumpHash(key uint64, buckets int) int {
+	var b, j int64 = -1, 0
+	for j < int64(buckets) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
+
+// LoomOf 获取指定键值在默认线程池中所绑定的线程 ID，参见 Pool.LoomOf。
+func LoomOf(key any) int { return defaultPool.LoomOf(key) }
+
+// LoomOf 获取指定键值所绑定的线程 ID。
+// key 为业务键值，如玩家 ID、房间 ID 等，支持整型、字符串及其他可格式化的类型。
+// 相同的键值总是映射至相同的线程，线程数量变化时仅有少量键值发生迁移。
+func (p *Pool) LoomOf(key any) int {
+	count := p.Count()
+	if count <= 1 {
+		return 0
+	}
+	return jumpHash(hashKey(key), count)
+}
+
+// loomOfKey 获取指定键值所绑定的线程。
+func (p *Pool) loomOfKey(key any) *loom {
+	list := p.looms()
+	if len(list) == 0 {
+		return nil
+	}
+	return list[jumpHash(hashKey(key), len(list))]
+}
+
+// RunInKey 在指定键值于默认线程池中所绑定的线程中执行任务，参见 Pool.RunInKey。
+func RunInKey(key any, callback func()) { defaultPool.RunInKey(key, callback) }
+
+// RunInKey 在指定键值所绑定的线程中执行任务。
+// key 为业务键值，必须为可比较的类型，不可比较的键值（如切片、映射及函数）将被拒绝并输出错误。
+// callback 为要执行的任务函数。
+// 同一键值的任务按照投递顺序串行执行，不同键值的任务之间互不阻塞。
+func (p *Pool) RunInKey(key any, callback func()) {
+	if callback == nil {
+		XLog.Critical("XLoom.RunInKey: callback can not be nil.")
+		return
+	}
+	if key == nil {
+		XLog.Critical("XLoom.RunInKey: key can not be nil.")
+		return
+	}
+	if !reflect.TypeOf(key).Comparable() {
+		XLog.Critical("XLoom.RunInKey: key of %T is not comparable.", key)
+		return
+	}
+
+	p.keyBoxesMu.Lock()
+	if p.keyBoxes == nil {
+		p.keyBoxes = make(map[any]*keyBox)
+	}
+	box := p.keyBoxes[key]
+	if box == nil {
+		box = &keyBox{pool: p, key: key}
+		p.keyBoxes[key] = box
+	}
+	box.tasks = append(box.tasks, callback)
+	schedule := !box.running
+	box.running = true
+	p.keyBoxesMu.Unlock()
+
+	if schedule {
+		box.schedule()
+	}
+}
+
+// schedule 将信箱投递至键值所绑定的线程。
+// 投递失败时（如队列已满）与 RunIn 一致丢弃信箱内待执行的任务，并移除信箱，避免任务永远无法执行。
+func (box *keyBox) schedule() {
+	if runIn(box.drain, box.pool.loomOfKey(box.key), PriorityNormal) {
+		return
+	}
+	box.pool.keyBoxesMu.Lock()
+	dropped := len(box.tasks)
+	box.tasks = nil
+	box.running = false
+	if box.pool.keyBoxes[box.key] == box {
+		delete(box.pool.keyBoxes, box.key)
+	}
+	box.pool.keyBoxesMu.Unlock()
+	XLog.Critical("XLoom.RunInKey: failed to schedule key %v, %v task(s) were dropped.", box.key, dropped)
+}
+
+// drain 在线程中执行信箱内的任务。
+// 每次仅执行投递时已存在的任务，新到达的任务将重新投递，以避免长时间占用线程。
+func (box *keyBox) drain() {
+	box.pool.keyBoxesMu.Lock()
+	tasks := box.tasks
+	box.tasks = nil
+	box.pool.keyBoxesMu.Unlock()
+
+	for _, task := range tasks {
+		func() {
+			defer XLog.Caught(false)
+			task()
+		}()
+	}
+
+	box.pool.keyBoxesMu.Lock()
+	if len(box.tasks) == 0 {
+		box.running = false
+		delete(box.pool.keyBoxes, box.key)
+		box.pool.keyBoxesMu.Unlock()
+		return
+	}
+	box.pool.keyBoxesMu.Unlock()
+	box.schedule()
+}
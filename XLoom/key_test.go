@@ -0,0 +1,130 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eframework-org/GO.UTIL/XPrefs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKey(t *testing.T) {
+	setup(XPrefs.New().Set(prefsCount, 4).Set(prefsStep, 10).Set(prefsQueue, 1000))
+
+	t.Run("LoomOf", func(t *testing.T) {
+		// 相同的键值应当映射至相同的线程
+		for _, key := range []any{1, int64(1001), "player-1", "room-2", 3.14} {
+			lid := LoomOf(key)
+			assert.GreaterOrEqual(t, lid, 0, "线程 ID 应当大于等于 0")
+			assert.Less(t, lid, Count(), "线程 ID 应当小于线程数量")
+			assert.Equal(t, lid, LoomOf(key), "相同的键值应当映射至相同的线程")
+		}
+
+		// 键值应当分布至所有线程
+		hits := make(map[int]int)
+		for i := range 1000 {
+			hits[LoomOf(i)]++
+		}
+		assert.Equal(t, Count(), len(hits), "键值应当分布至所有线程")
+
+		// 线程数量变化时仅有少量键值发生迁移
+		moved := 0
+		for i := range 1000 {
+			if jumpHash(hashKey(i), 4) != jumpHash(hashKey(i), 5) {
+				moved++
+			}
+		}
+		assert.Less(t, moved, 400, "扩容后迁移的键值应当接近 1/5")
+	})
+
+	t.Run("RunInKey", func(t *testing.T) {
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		orders := make(map[int][]int)
+		looms := make(map[int]int)
+
+		for i := range 100 {
+			for key := range 10 {
+				wg.Add(1)
+				RunInKey(key, func() {
+					defer wg.Done()
+					mu.Lock()
+					orders[key] = append(orders[key], i)
+					looms[key] = ID()
+					mu.Unlock()
+				})
+			}
+		}
+
+		done := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second * 3):
+			t.Fatal("键值任务执行超时")
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		for key := range 10 {
+			assert.Len(t, orders[key], 100, "键值任务应当全部执行")
+			assert.IsIncreasing(t, orders[key], "同一键值的任务应当按照投递顺序执行")
+			assert.Equal(t, LoomOf(key), looms[key], "键值任务应当在绑定的线程中执行")
+		}
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		done := make(chan struct{})
+		RunInKey("panic", func() { panic("test key panic") })
+		RunInKey("panic", func() { close(done) })
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("发生异常后键值任务应当继续执行")
+		}
+	})
+	t.Run("Comparable", func(t *testing.T) {
+		assert.NotPanics(t, func() { RunInKey([]int{1}, func() {}) }, "不可比较的键值不应当导致异常")
+		assert.NotPanics(t, func() { RunInKey(map[string]int{}, func() {}) })
+		defaultPool.keyBoxesMu.Lock()
+		assert.Empty(t, defaultPool.keyBoxes, "不可比较的键值不应当创建信箱")
+		defaultPool.keyBoxesMu.Unlock()
+	})
+
+	t.Run("Full", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 1).Set(prefsStep, 10).Set(prefsQueue, 1))
+		defer setup(XPrefs.New().Set(prefsCount, 4).Set(prefsStep, 10).Set(prefsQueue, 1000))
+
+		block := make(chan struct{})
+		started := make(chan struct{})
+		RunIn(func() {
+			close(started)
+			<-block
+		})
+		<-started
+		RunIn(func() {}) // 占满队列
+
+		RunInKey("full", func() { t.Error("投递失败的键值任务不应当执行") })
+		defaultPool.keyBoxesMu.Lock()
+		assert.NotContains(t, defaultPool.keyBoxes, "full", "投递失败的信箱应当被移除")
+		defaultPool.keyBoxesMu.Unlock()
+
+		close(block)
+		time.Sleep(time.Millisecond * 50)
+		done := make(chan struct{})
+		RunInKey("full", func() { close(done) })
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("队列空闲后键值任务应当重新投递并执行")
+		}
+	})
+}
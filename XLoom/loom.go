@@ -5,15 +5,16 @@
 package XLoom
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/eframework-org/GO.UTIL/XLog"
-	"github.com/eframework-org/GO.UTIL/XPrefs"
 	"github.com/eframework-org/GO.UTIL/XTime"
 	"github.com/illumitacit/gostd/quit"
 	"github.com/petermattis/goid"
@@ -21,300 +22,309 @@ import (
 )
 
 const (
-	prefsCount        = "Loom/Count" // 线程数量配置键，用于设置线程池大小
-	prefsCountDefault = 1            // 默认线程数量，当未配置时使用此值
-	prefsStep         = "Loom/Step"  // 更新步长配置键，用于控制线程更新频率（毫秒）
-	prefsStepDefault  = 10           // 默认更新步长，当未配置时使用此值
-	prefsQueue        = "Loom/Queue" // 队列大小配置键，用于设置每个线程的任务队列容量
-	prefsQueueDefault = 50000        // 默认队列大小，当未配置时使用此值
+	prefsCount                = "Loom/Count"         // 线程数量配置键，用于设置线程池大小
+	prefsCountDefault         = 1                    // 默认线程数量，当未配置时使用此值
+	prefsStep                 = "Loom/Step"          // 更新步长配置键，用于控制线程更新频率（毫秒）
+	prefsStepDefault          = 10                   // 默认更新步长，当未配置时使用此值
+	prefsQueue                = "Loom/Queue"         // 队列大小配置键，用于设置每个线程的任务队列容量
+	prefsQueueDefault         = 50000                // 默认队列大小，当未配置时使用此值
+	prefsStarve               = "Loom/Starve"        // 饥饿阈值配置键，用于设置低优先级任务最多被连续跳过的次数
+	prefsStarveDefault        = 16                   // 默认饥饿阈值，当未配置时使用此值
+	prefsDrainTimeout         = "Loom/DrainTimeout"  // 排空超时配置键，用于设置退出时执行剩余任务的最长时间（毫秒）
+	prefsDrainTimeoutDefault  = 3000                 // 默认排空超时，当未配置时使用此值
+	prefsDrainTimer           = "Loom/DrainTimer"    // 排空定时器配置键，用于设置退出时剩余超时调用的处理方式：fire 或 cancel
+	prefsDrainTimerDefault    = drainTimerCancel     // 默认排空定时器处理方式，当未配置时使用此值
+	prefsBudget               = "Loom/Budget"        // 执行预算配置键，用于设置任务、定时器回调及帧更新的最长执行时间（毫秒）
+	prefsBudgetDefault        = 500                  // 默认执行预算，当未配置时使用此值
+	prefsLegacyMetrics        = "Loom/LegacyMetrics" // 旧版度量配置键，用于设置是否同时导出按线程命名的旧版度量
+	prefsLegacyMetricsDefault = false                // 默认不导出旧版度量，当未配置时使用此值
+	prefsMetricsPrefix        = "Loom/MetricsPrefix" // 度量前缀配置键，用于设置线程池导出的度量名称前缀
+	prefsMetricsPrefixDefault = "xloom"              // 默认度量前缀，当未配置时使用此值
+	prefsLockThread           = "Loom/LockThread"    // 线程锁定配置键，用于设置是否将每个线程锁定至独立的系统线程
+	prefsLockThreadDefault    = false                // 默认不锁定系统线程，当未配置时使用此值
+	prefsAffinity             = "Loom/Affinity"      // CPU 亲和性配置键，用于设置各线程绑定的 CPU 集合，如 ["0-1", "2-3"]
+	prefsWorkers              = "Loom/Workers"       // 工作者数量配置键，用于设置执行阻塞任务的工作池大小
+	prefsWorkersDefault       = 64                   // 默认工作者数量，当未配置时使用此值
+	prefsWorkerQueue          = "Loom/WorkerQueue"   // 工作队列配置键，用于设置工作池的任务队列容量
+	prefsWorkerQueueDefault   = 10000                // 默认工作队列大小，当未配置时使用此值
 )
 
 var (
-	loomInitMu        sync.Mutex            // 初始化互斥锁，用于保护初始化过程
-	loomPause         []bool                // 线程暂停状态，true 表示暂停，false 表示运行
-	loomPauseSig      []chan bool           // 线程暂停信号，用于通知线程暂停状态的变化
-	loomSetupSig      []chan os.Signal      // 线程设置信号，用于接收退出信号
-	loomCloseSig      []chan bool           // 线程退出信号
-	loomCloseWait     sync.WaitGroup        // 等待所有处理器完成
-	loomIDMap         = make(map[int64]int) // 线程映射表，用于存储 goroutine ID 到 loom ID 的映射关系
-	loomIDMu          sync.Mutex            // 线程映射表互斥锁，用于保护映射表的并发访问
-	loomCount         int                   // 线程总数，表示当前运行的线程数量
-	loomTask          []chan func()         // 线程任务队列，每个线程一个独立的任务通道
-	loomFPS           []int                 // 线程刷新帧率统计，记录每个线程的每秒刷新次数
-	loomFPSGauges     []prometheus.Gauge    // 线程刷新帧率度量
-	loomQPS           []int                 // 线程处理速率统计，记录每个线程的每秒处理次数
-	loomQPSGauges     []prometheus.Gauge    // 线程处理速率度量
-	loomQueryCounters []prometheus.Counter  // 线程处理总数度量
-	loomQueryCounter  prometheus.Counter    // 所有线程处理总数度量
+	loomIDMap atomic.Pointer[map[int64]*loom] // 线程映射表，写时复制，用于存储 goroutine ID 到线程的映射关系
+	loomIDMu  sync.Mutex                      // 线程映射表互斥锁，用于保护映射表的写入
 )
 
-func init() { setup(XPrefs.Asset()) }
+// loom 定义了单个线程的运行状态。
+type loom struct {
+	id          int                                // 线程 ID
+	pool        *Pool                              // 线程所属的线程池
+	mu          sync.RWMutex                       // 投递互斥锁，用于保护线程退役时的任务迁移
+	retired     bool                               // 是否已退役，退役后投递的任务将转发至其他线程
+	draining    bool                               // 是否正在排空，排空时不再接收新的任务
+	task        [priorityCount]chan taskItem       // 任务队列，每个优先级一个独立的任务通道
+	wake        chan struct{}                      // 唤醒信号，用于通知线程存在待处理的任务
+	skip        [priorityCount]int                 // 任务跳过计数，记录非空通道被连续跳过的次数
+	pause       atomic.Bool                        // 暂停状态，true 表示暂停，false 表示运行
+	pauseSig    chan bool                          // 暂停信号，用于通知线程暂停状态的变化
+	setupSig    chan os.Signal                     // 设置信号，用于接收退出信号
+	closeSig    chan bool                          // 退出信号，true 表示排空后退出
+	closeWait   sync.WaitGroup                     // 等待线程退出
+	gid         atomic.Int64                       // 线程所在的 goroutine ID
+	tid         atomic.Int64                       // 线程所在的系统线程 ID，未锁定系统线程时为 -1
+	cpus        []int                              // 线程绑定的 CPU 集合，为空时不设置 CPU 亲和性
+	fps         atomic.Uint64                      // 刷新帧率统计，记录线程的每秒刷新次数（float64 位模式）
+	qps         atomic.Uint64                      // 处理速率统计，记录线程的每秒处理次数（float64 位模式）
+	queries     atomic.Uint64                      // 处理总数统计
+	lanes       [priorityCount]atomic.Uint64       // 各优先级处理总数统计
+	latency     [priorityCount]prometheus.Observer // 各优先级排队时延度量
+	timers      timerQueue                         // 定时器队列
+	updateHooks atomic.Pointer[[]*updateHook]      // 帧更新回调，写时复制的回调列表
+	lateHooks   atomic.Pointer[[]*updateHook]      // 帧后更新回调，写时复制的回调列表
+	watches     [watchCount]watch                  // 执行监视状态，用于检测慢执行
+	durations   [watchCount]prometheus.Observer    // 执行耗时度量
+}
 
-// setup 初始化线程系统。
-func setup(prefs XPrefs.IBase) {
-	if prefs == nil {
-		XLog.Panic("XLoom.Init: prefs is nil.")
-		return
+// newLoom 创建并启动线程池中的一个线程。
+func (p *Pool) newLoom(id int) *loom {
+	l := &loom{
+		id:       id,
+		pool:     p,
+		wake:     make(chan struct{}, 1),
+		pauseSig: make(chan bool, 1),
+		setupSig: make(chan os.Signal, 1),
+		closeSig: make(chan bool, 1),
 	}
-
-	loomInitMu.Lock()
-	defer loomInitMu.Unlock()
-
-	count := prefs.GetInt(prefsCount, prefsCountDefault)
-	step := prefs.GetInt(prefsStep, prefsStepDefault)
-	queue := prefs.GetInt(prefsQueue, prefsQueueDefault)
-
-	if count <= 0 || step <= 0 || queue <= 0 {
-		XLog.Panic("XLoom.Init: invalid parameters, count: %v, step: %v, queue: %v.", count, step, queue)
-		return
+	if len(p.affinity) > 0 {
+		l.cpus = p.affinity[id%len(p.affinity)]
 	}
-
-	// 关闭所有线程。
-	if len(loomCloseSig) > 0 {
-		for _, ch := range loomCloseSig {
-			ch <- true
-		}
-		loomCloseWait.Wait()
+	for lane := range priorityCount {
+		l.task[lane] = make(chan taskItem, p.queue)
 	}
-	loomCloseWait = sync.WaitGroup{}
 
-	// 注销数据度量。
-	if len(loomFPSGauges) > 0 {
-		for _, gauge := range loomFPSGauges {
-			prometheus.Unregister(gauge)
-		}
-	}
-	if len(loomQPSGauges) > 0 {
-		for _, gauge := range loomQPSGauges {
-			prometheus.Unregister(gauge)
-		}
-	}
-	if len(loomQueryCounters) > 0 {
-		for _, counter := range loomQueryCounters {
-			prometheus.Unregister(counter)
-		}
+	// 获取数据度量。
+	for lane := range priorityCount {
+		l.latency[lane] = p.metrics.latency.WithLabelValues(fmt.Sprint(id), lane.String())
 	}
-	if loomQueryCounter != nil {
-		prometheus.Unregister(loomQueryCounter)
+	for kind := range watchCount {
+		l.durations[kind] = p.metrics.duration.WithLabelValues(fmt.Sprint(id), watchKinds[kind])
 	}
 
-	loomCount = count
-
-	loomTask = make([]chan func(), count)
-	loomSetupSig = make([]chan os.Signal, count)
-	loomCloseSig = make([]chan bool, count)
-	loomPause = make([]bool, count)
-	loomPauseSig = make([]chan bool, count)
-	loomFPS = make([]int, count)
-	loomFPSGauges = make([]prometheus.Gauge, count)
-	loomQPS = make([]int, count)
-	loomQPSGauges = make([]prometheus.Gauge, count)
-	loomQueryCounters = make([]prometheus.Counter, count)
-	loomQueryCounter = prometheus.NewCounter(prometheus.CounterOpts{
-		Name: "xloom_query_total",
-		Help: "Total number of queries processed by all looms.",
-	})
-	prometheus.MustRegister(loomQueryCounter)
-
-	for i := range count {
-		loomTask[i] = make(chan func(), queue)
-		loomSetupSig[i] = make(chan os.Signal, 1)
-		loomCloseSig[i] = make(chan bool, 1)
-		loomPauseSig[i] = make(chan bool, 1)
-	}
-
-	setupTimer(count)
-
 	wg := sync.WaitGroup{}
-	for i := range count {
-		wg.Add(1)
-
-		// 注册数据度量。
-		loomFPSGauges[i] = prometheus.NewGauge(prometheus.GaugeOpts{
-			Name: fmt.Sprintf("xloom_fps_%v", i),
-			Help: fmt.Sprintf("Frames per second for loom %v.", i),
-		})
-		prometheus.MustRegister(loomFPSGauges[i])
-
-		loomQPSGauges[i] = prometheus.NewGauge(prometheus.GaugeOpts{
-			Name: fmt.Sprintf("xloom_qps_%v", i),
-			Help: fmt.Sprintf("Queries per second for loom %v.", i),
-		})
-		prometheus.MustRegister(loomQPSGauges[i])
-
-		loomQueryCounters[i] = prometheus.NewCounter(prometheus.CounterOpts{
-			Name: fmt.Sprintf("xloom_query_total_%v", i),
-			Help: fmt.Sprintf("Total number of queries processed by loom %v.", i),
+	wg.Add(1)
+	doneOnce := sync.Once{}
+	RunAsync(func() {
+		l.loop(func() {
+			doneOnce.Do(func() { // 确保只调用一次，否则recover后会重复调用
+				wg.Done() // 确保线程启动完成
+			})
 		})
-		prometheus.MustRegister(loomQueryCounters[i])
-
-		doneOnce := sync.Once{}
-		RunAsyncT1(func(pid int) {
-			setupSig := loomSetupSig[i]
-			signal.Notify(setupSig, syscall.SIGTERM, syscall.SIGINT)
-			pauseSig := loomPauseSig[i]
-			closeSig := loomCloseSig[i]
-
-			loomCloseWait.Add(1)
-			quit.GetWaiter().Add(1)
-			defer func() {
-				quit.GetWaiter().Done()
-				loomCloseWait.Done()
-			}()
+	}, true)
+	wg.Wait()
+	return l
+}
 
-			loomIDMu.Lock()
-			loomIDMap[goid.Get()] = pid
-			loomIDMu.Unlock()
+// close 关闭线程并删除数据度量。
+// drain 为是否排空线程，为 false 时线程中未处理的任务及定时器将被丢弃。
+func (l *loom) close(drain bool) {
+	select {
+	case l.closeSig <- drain:
+	default: // 线程已退出或已存在退出信号
+	}
+	l.closeWait.Wait()
 
-			updateTicker := time.NewTicker(time.Millisecond * time.Duration(step))
-			defer updateTicker.Stop()
+	bindLoom(l.gid.Load(), 0, l)
 
-			doneOnce.Do(func() { // 确保只调用一次，否则recover后会重复调用
-				wg.Done() // 确保线程启动完成
-			})
+	m := l.pool.metrics
+	for p := range priorityCount {
+		m.latency.DeleteLabelValues(fmt.Sprint(l.id), p.String())
+	}
+	for kind := range watchCount {
+		m.slow.DeleteLabelValues(fmt.Sprint(l.id), watchKinds[kind])
+		m.duration.DeleteLabelValues(fmt.Sprint(l.id), watchKinds[kind])
+	}
+}
 
-			lastTime := XTime.GetMillisecond()
-			metricsTime := 0
-			frameCount := 0
-			queryCount := 0
-
-			for {
-				if loomPause[pid] {
-					select {
-					case <-updateTicker.C:
-						// 在暂停状态下重置计数器和指标
-						frameCount = 0
-						queryCount = 0
-						loomFPS[pid] = 0
-						loomFPSGauges[pid].Set(0)
-						loomQPS[pid] = 0
-						loomQPSGauges[pid].Set(0)
-						lastTime = XTime.GetMillisecond() // 更新时间戳，避免恢复后的突然跳变
-					case val := <-pauseSig:
-						XLog.Notice("XLoom.Loop(%v): receive signal of pause(%v).", pid, val)
-					case <-closeSig:
-						XLog.Notice("XLoom.Loop(%v): receive signal of close.", pid)
-						return
-					case sig, ok := <-setupSig:
-						if ok {
-							XLog.Notice("XLoom.Loop(%v): receive signal of %v.", i, sig.String())
-						} else {
-							XLog.Notice("XLoom.Loop(%v): channel of signal is closed.", i)
-						}
-						return
-					case <-quit.GetQuitChannel():
-						XLog.Notice("XLoom.Loop(%v): receive signal of quit.", pid)
-						return
-					}
+// loop 运行线程的主循环。
+// started 为线程启动完成的回调。
+func (l *loom) loop(started func()) {
+	pid := l.id
+	signal.Notify(l.setupSig, syscall.SIGTERM, syscall.SIGINT)
+
+	l.closeWait.Add(1)
+	quit.GetWaiter().Add(1)
+	defer func() {
+		signal.Stop(l.setupSig)
+		quit.GetWaiter().Done()
+		l.closeWait.Done()
+	}()
+
+	gid := goid.Get()
+	bindLoom(l.gid.Swap(gid), gid, l) // 主循环因异常重启时解除原有 goroutine 的映射
+	l.bindThread()
+
+	updateTicker := time.NewTicker(time.Millisecond * time.Duration(l.pool.step))
+	defer updateTicker.Stop()
+
+	runTimer := l.runTimer
+	started()
+
+	lastTime := XTime.GetMillisecond()
+	metricsTime := 0
+	frameCount := 0
+	queryCount := 0
+
+	for {
+		if l.pause.Load() {
+			select {
+			case <-updateTicker.C:
+				// 在暂停状态下重置计数器和指标
+				frameCount = 0
+				queryCount = 0
+				l.storeRate(0, 0)
+				lastTime = XTime.GetMillisecond() // 更新时间戳，避免恢复后的突然跳变
+			case val := <-l.pauseSig:
+				XLog.Notice("XLoom.Loop(%v): receive signal of pause(%v).", pid, val)
+			case drain := <-l.closeSig:
+				XLog.Notice("XLoom.Loop(%v): receive signal of close.", pid)
+				if drain {
+					l.drain()
+				}
+				return
+			case sig, ok := <-l.setupSig:
+				if ok {
+					XLog.Notice("XLoom.Loop(%v): receive signal of %v.", pid, sig.String())
 				} else {
-					nowTime := XTime.GetMillisecond()
-					deltaTime := nowTime - lastTime
-					lastTime = nowTime
-
-					metricsTime += deltaTime
-					if metricsTime >= 1000 {
-						fps := float64(frameCount) * 1000 / float64(metricsTime)
-						ifps := int(fps)
-						qps := float64(queryCount) * 1000 / float64(metricsTime)
-						iqps := int(qps)
-						loomFPS[pid] = ifps
-						loomFPSGauges[pid].Set(fps)
-						loomQPS[pid] = iqps
-						loomQPSGauges[pid].Set(qps)
-						frameCount = 0
-						queryCount = 0
-						metricsTime = 0
-					}
+					XLog.Notice("XLoom.Loop(%v): channel of signal is closed.", pid)
+				}
+				l.drain()
+				return
+			case <-quit.GetQuitChannel():
+				XLog.Notice("XLoom.Loop(%v): receive signal of quit.", pid)
+				l.drain()
+				return
+			}
+		} else {
+			nowTime := XTime.GetMillisecond()
+			deltaTime := nowTime - lastTime
+			lastTime = nowTime
+
+			metricsTime += deltaTime
+			if metricsTime >= 1000 {
+				fps := float64(frameCount) * 1000 / float64(metricsTime)
+				qps := float64(queryCount) * 1000 / float64(metricsTime)
+				l.storeRate(fps, qps)
+				frameCount = 0
+				queryCount = 0
+				metricsTime = 0
+			}
 
-					select {
-					case runIn, ok := <-loomTask[pid]:
-						if ok {
-							queryCount++
-							loomQueryCounters[pid].Inc()
-							loomQueryCounter.Inc()
-							runIn()
-						} else {
-							XLog.Error("XLoom.Loop(%v): get runin with ret false.", pid)
-						}
-					case <-updateTicker.C:
-						frameCount++
-						updateTimer(pid, deltaTime)
-					case val := <-pauseSig:
-						XLog.Notice("XLoom.Loop(%v): receive signal of pause(%v).", pid, val)
-					case <-closeSig:
-						XLog.Notice("XLoom.Loop(%v): receive signal of close.", pid)
-						return
-					case sig, ok := <-setupSig:
-						if ok {
-							XLog.Notice("XLoom.Loop(%v): receive signal of %v.", i, sig.String())
-						} else {
-							XLog.Notice("XLoom.Loop(%v): channel of signal is closed.", i)
-						}
-						return
-					case <-quit.GetQuitChannel():
-						XLog.Notice("XLoom.Loop(%v): receive signal of quit.", pid)
-						return
+			select {
+			case <-l.wake:
+				if runIn, lane := l.pickTask(); runIn.fn != nil {
+					queryCount++
+					l.queries.Add(1)
+					l.lanes[lane].Add(1)
+					l.pool.metricsQuery.Add(1)
+					l.latency[lane].Observe(time.Since(runIn.at).Seconds())
+					if l.pendingTask() {
+						l.wakeup() // 存在剩余任务，重新唤醒以便与帧更新交替执行
 					}
+					l.track(watchTask, funcPC(runIn.fn), runIn.fn)
+				}
+			case <-updateTicker.C:
+				frameCount++
+				l.track(watchFrame, 0, func() {
+					runUpdate(&l.updateHooks, deltaTime)
+					l.timers.update(deltaTime, runTimer)
+					runUpdate(&l.lateHooks, deltaTime)
+				})
+			case val := <-l.pauseSig:
+				XLog.Notice("XLoom.Loop(%v): receive signal of pause(%v).", pid, val)
+			case drain := <-l.closeSig:
+				XLog.Notice("XLoom.Loop(%v): receive signal of close.", pid)
+				if drain {
+					l.drain()
+				}
+				return
+			case sig, ok := <-l.setupSig:
+				if ok {
+					XLog.Notice("XLoom.Loop(%v): receive signal of %v.", pid, sig.String())
+				} else {
+					XLog.Notice("XLoom.Loop(%v): channel of signal is closed.", pid)
 				}
+				l.drain()
+				return
+			case <-quit.GetQuitChannel():
+				XLog.Notice("XLoom.Loop(%v): receive signal of quit.", pid)
+				l.drain()
+				return
 			}
-		}, i, true)
+		}
 	}
-
-	XLog.Notice("XLoom.Init: allocated %v loom(s).", count)
-	loomCount = count
-	wg.Wait()
 }
 
+// Pause 暂停默认线程池中的指定线程或所有线程，参见 Pool.Pause。
+func Pause(loomID ...int) { defaultPool.Pause(loomID...) }
+
 // Pause 暂停指定线程或所有线程。
 // loomID 为可选的目标线程 ID，如果未指定，则暂停所有线程。
-func Pause(loomID ...int) {
+func (p *Pool) Pause(loomID ...int) {
 	if len(loomID) == 1 {
 		lid := loomID[0]
 		if lid < 0 {
 			XLog.Critical("XLoom.Pause: loom id of %v can not be zero or negative.", lid)
 			return
 		}
-		if lid >= loomCount {
-			XLog.Critical("XLoom.Pause: loom id of %v can not equals or greater than: %v", lid, Count())
+		l := p.getLoom(lid)
+		if l == nil {
+			XLog.Critical("XLoom.Pause: loom id of %v can not equals or greater than: %v", lid, p.Count())
 			return
 		}
-		loomPause[lid] = true
-		loomPauseSig[lid] <- true
+		l.pause.Store(true)
+		l.pauseSig <- true
 	} else {
-		for lid := range loomPause {
-			loomPause[lid] = true
-			loomPauseSig[lid] <- true
+		for _, l := range p.looms() {
+			l.pause.Store(true)
+			l.pauseSig <- true
 		}
 	}
 }
 
+// Resume 恢复默认线程池中的指定线程或所有线程，参见 Pool.Resume。
+func Resume(loomID ...int) { defaultPool.Resume(loomID...) }
+
 // Resume 恢复指定线程或所有线程。
 // loomID 为可选的目标线程 ID，如果未指定，则恢复所有线程。
-func Resume(loomID ...int) {
+func (p *Pool) Resume(loomID ...int) {
 	if len(loomID) == 1 {
 		lid := loomID[0]
 		if lid < 0 {
 			XLog.Critical("XLoom.Resume: loom id of %v can not be zero or negative.", lid)
 			return
 		}
-		if lid >= loomCount {
-			XLog.Critical("XLoom.Resume: loom id of %v can not equals or greater than: %v.", lid, Count())
+		l := p.getLoom(lid)
+		if l == nil {
+			XLog.Critical("XLoom.Resume: loom id of %v can not equals or greater than: %v.", lid, p.Count())
 			return
 		}
-		loomPause[lid] = false
-		loomPauseSig[lid] <- false
+		l.pause.Store(false)
+		l.pauseSig <- false
 	} else {
-		for lid := range loomPause {
-			loomPause[lid] = false
-			loomPauseSig[lid] <- false
+		for _, l := range p.looms() {
+			l.pause.Store(false)
+			l.pauseSig <- false
 		}
 	}
 }
 
+// RunIn 在默认线程池的指定线程中执行任务，参见 Pool.RunIn。
+func RunIn(callback func(), loomID ...int) { defaultPool.RunIn(callback, loomID...) }
+
 // RunIn 在指定线程中执行任务。
 // callback 为要执行的任务函数。
 // loomID 为可选的目标线程 ID，如果未指定，默认在线程 0 中执行。
-func RunIn(callback func(), loomID ...int) {
+// 任务以普通优先级投递，如需指定优先级请使用 RunInWith。
+func (p *Pool) RunIn(callback func(), loomID ...int) {
 	if callback == nil {
 		XLog.Critical("XLoom.RunIn: callback can not be nil.")
 		return
@@ -329,77 +339,208 @@ func RunIn(callback func(), loomID ...int) {
 		XLog.Critical("XLoom.RunIn: loom id of %v can not be zero or negative.", lid)
 		return
 	}
-	if lid >= loomCount {
-		XLog.Critical("XLoom.RunIn: loom id of %v can not equals or greater than: %v.", lid, Count())
+	l := p.getLoom(lid)
+	if l == nil {
+		XLog.Critical("XLoom.RunIn: loom id of %v can not equals or greater than: %v.", lid, p.Count())
 		return
 	}
-	ch := loomTask[lid]
-	select {
-	case ch <- callback:
-	default:
-		XLog.Critical("XLoom.RunIn: too many runins of %v.", lid)
+	runIn(callback, l, PriorityNormal)
+}
+
+// RunInWith 使用可选参数在默认线程池的指定线程中执行任务，参见 Pool.RunInWith。
+func RunInWith(callback func(), options ...TaskOption) { defaultPool.RunInWith(callback, options...) }
+
+// RunInWith 使用可选参数在指定线程中执行任务。
+// callback 为要执行的任务函数。
+// options 为可选的投递参数，如 WithLoom、WithPriority 等，默认以普通优先级在线程 0 中执行。
+func (p *Pool) RunInWith(callback func(), options ...TaskOption) {
+	if callback == nil {
+		XLog.Critical("XLoom.RunInWith: callback can not be nil.")
+		return
+	}
+	opt := taskOption{priority: PriorityNormal}
+	for _, option := range options {
+		if option != nil {
+			option(&opt)
+		}
+	}
+	if opt.loomID < 0 {
+		XLog.Critical("XLoom.RunInWith: loom id of %v can not be zero or negative.", opt.loomID)
+		return
+	}
+	l := p.getLoom(opt.loomID)
+	if l == nil {
+		XLog.Critical("XLoom.RunInWith: loom id of %v can not equals or greater than: %v.", opt.loomID, p.Count())
+		return
+	}
+	if opt.priority < PriorityHigh || opt.priority >= priorityCount {
+		XLog.Critical("XLoom.RunInWith: priority of %v is invalid.", opt.priority)
+		return
+	}
+	runIn(callback, l, opt.priority)
+}
+
+// runIn 将任务投递至指定线程指定优先级的任务队列。
+// 若线程已退役，则转发至迁移的目标线程。
+// 返回是否投递成功，队列已满时返回 false。
+func runIn(callback func(), l *loom, priority Priority) bool {
+	for l != nil {
+		l.mu.RLock()
+		if l.draining {
+			l.mu.RUnlock()
+			XLog.Critical("XLoom.RunIn: loom %v is draining, runin was rejected.", l.id)
+			return false
+		}
+		if !l.retired {
+			select {
+			case l.task[priority] <- taskItem{fn: callback, at: time.Now()}:
+				l.mu.RUnlock()
+				l.wakeup()
+				return true
+			default:
+				l.mu.RUnlock()
+				XLog.Critical("XLoom.RunIn: too many runins of %v in %v lane.", l.id, priority)
+				return false
+			}
+		}
+		l.mu.RUnlock()
+		l = l.pool.migrateTarget(l.id)
 	}
+	return false
 }
 
+// Count 返回默认线程池的线程总数。
+func Count() int { return defaultPool.Count() }
+
 // Count 返回线程总数。
-func Count() int { return loomCount }
+func (p *Pool) Count() int { return len(p.looms()) }
+
+// bindLoom 更新线程映射表，解除 oldGID 与线程的映射并将 newGID 映射至线程。
+// oldGID 或 newGID 为 0 时忽略对应的操作。
+func bindLoom(oldGID, newGID int64, l *loom) {
+	loomIDMu.Lock()
+	defer loomIDMu.Unlock()
+
+	// 写时复制，读取映射表时无需加锁。
+	var m map[int64]*loom
+	if old := loomIDMap.Load(); old != nil {
+		m = make(map[int64]*loom, len(*old)+1)
+		for k, v := range *old {
+			m[k] = v
+		}
+	} else {
+		m = make(map[int64]*loom)
+	}
+	if bound, ok := m[oldGID]; oldGID != 0 && ok && bound == l {
+		delete(m, oldGID)
+	}
+	if newGID != 0 {
+		m[newGID] = l
+	}
+	loomIDMap.Store(&m)
+}
+
+// currentLoom 获取当前 goroutine 所在的线程，线程可以属于任意线程池，未绑定线程时返回 nil。
+func currentLoom() *loom {
+	if m := loomIDMap.Load(); m != nil {
+		return (*m)[goid.Get()]
+	}
+	return nil
+}
+
+// ID 获取当前 goroutine 在默认线程池中所在的 loom ID，参见 Pool.ID。
+func ID(goroutineID ...int64) int { return defaultPool.ID(goroutineID...) }
 
-// ID 获取当前 goroutine 所在的 loom ID。
+// ID 获取当前 goroutine 所在的 loom ID，可在任意 goroutine 中调用。
 // 如果指定了 goroutineID，则返回该线程的线程 ID。
-// 如果线程未绑定线程，返回 -1。
-func ID(goroutineID ...int64) int {
-	// TONOTICE: 不使用sync.Map避免引起值类型的装箱和拆箱
-	// 尽量在业务线程中调用，业务线程之外调用可能存在并发读写问题
+// 如果线程未绑定该线程池的线程，返回 -1。
+func (p *Pool) ID(goroutineID ...int64) int {
+	// TONOTICE: 不使用sync.Map避免引起值类型的装箱和拆箱，映射表仅在线程启动及关闭时写时复制
 	var tgid int64
 	if len(goroutineID) == 1 {
 		tgid = goroutineID[0]
 	} else {
 		tgid = goid.Get()
 	}
-	if pid, ok := loomIDMap[tgid]; ok {
-		return pid
+	if m := loomIDMap.Load(); m != nil {
+		if l, ok := (*m)[tgid]; ok && l.pool == p {
+			return l.id
+		}
+	}
+	return -1
+}
+
+// loomContextKey 定义了上下文中线程 ID 的键。
+type loomContextKey struct{}
+
+// NewContext 返回携带线程 ID 的上下文，可以通过 LoomFrom 获取。
+// ctx 为父级上下文，loomID 为线程 ID。
+func NewContext(ctx context.Context, loomID int) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, loomContextKey{}, loomID)
+}
+
+// LoomFrom 获取上下文中携带的线程 ID，用于替代基于 goroutine ID 的 ID 查找。
+// ctx 为由 NewContext 创建的上下文，如 Group.GoIn 传入任务函数的上下文。
+// 返回线程 ID，如果上下文未携带线程 ID 则返回 -1。
+func LoomFrom(ctx context.Context) int {
+	if ctx == nil {
+		return -1
+	}
+	if lid, ok := ctx.Value(loomContextKey{}).(int); ok {
+		return lid
 	}
 	return -1
 }
 
+// FPS 获取默认线程池中指定线程的刷新帧率，参见 Pool.FPS。
+func FPS(loomID ...int) int { return defaultPool.FPS(loomID...) }
+
 // FPS 获取指定线程的刷新帧率。
 // loomID 为可选的目标线程 ID，如果未指定，返回当前线程的刷新帧率。
 // 返回每秒帧数，如果线程 ID 无效则返回 0。
-func FPS(loomID ...int) int {
+func (p *Pool) FPS(loomID ...int) int {
 	lid := -1
 	if len(loomID) == 1 {
 		lid = loomID[0]
 	} else {
-		lid = ID()
+		lid = p.ID()
 	}
 	if lid < 0 {
 		XLog.Critical("XLoom.FPS: loom id of %v can not be zero or negative.", lid)
 		return 0
 	}
-	if lid >= loomCount {
-		XLog.Critical("XLoom.FPS: loom id of %v can not equals or greater than: %v.", lid, Count())
+	l := p.getLoom(lid)
+	if l == nil {
+		XLog.Critical("XLoom.FPS: loom id of %v can not equals or greater than: %v.", lid, p.Count())
 		return 0
 	}
-	return loomFPS[lid]
+	return int(l.loadFPS())
 }
 
+// QPS 获取默认线程池中指定线程的处理速率，参见 Pool.QPS。
+func QPS(loomID ...int) int { return defaultPool.QPS(loomID...) }
+
 // QPS 获取指定线程的处理速率。
 // loomID 为可选的目标线程 ID，如果未指定，返回当前线程的处理速率。
 // 返回每秒处理的任务数，如果线程 ID 无效则返回 0。
-func QPS(loomID ...int) int {
+func (p *Pool) QPS(loomID ...int) int {
 	lid := -1
 	if len(loomID) == 1 {
 		lid = loomID[0]
 	} else {
-		lid = ID()
+		lid = p.ID()
 	}
 	if lid < 0 {
 		XLog.Critical("XLoom.QPS: loom id of %v can not be zero or negative.", lid)
 		return 0
 	}
-	if lid >= loomCount {
-		XLog.Critical("XLoom.QPS: loom id of %v can not equals or greater than: %v.", lid, Count())
+	l := p.getLoom(lid)
+	if l == nil {
+		XLog.Critical("XLoom.QPS: loom id of %v can not equals or greater than: %v.", lid, p.Count())
 		return 0
 	}
-	return loomQPS[lid]
+	return int(l.loadQPS())
 }
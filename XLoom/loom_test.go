@@ -5,12 +5,12 @@
 package XLoom
 
 import (
+	"context"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/eframework-org/GO.UTIL/XPrefs"
-	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -95,13 +95,13 @@ func TestLoom(t *testing.T) {
 
 		// 验证正常运行时的指标
 		assert.InDelta(t, expectedFPS, FPS(0), float64(expectedFPS)*0.3, "FPS should be around %d (±30%%)", expectedFPS)
-		assert.InDelta(t, expectedFPS, testutil.ToFloat64(loomFPSGauges[0]), float64(expectedFPS)*0.3, "FPS should be around %d (±30%%)", expectedFPS)
+		assert.InDelta(t, expectedFPS, gather(t, "xloom_fps", "loom", "0"), float64(expectedFPS)*0.3, "FPS should be around %d (±30%%)", expectedFPS)
 
 		assert.InDelta(t, expectedQPS, QPS(0), float64(expectedQPS)*0.3, "QPS should be around %d (±30%%)", expectedQPS)
-		assert.InDelta(t, expectedQPS, testutil.ToFloat64(loomQPSGauges[0]), float64(expectedQPS)*0.3, "QPS should be around %d (±30%%)", expectedQPS)
+		assert.InDelta(t, expectedQPS, gather(t, "xloom_qps", "loom", "0"), float64(expectedQPS)*0.3, "QPS should be around %d (±30%%)", expectedQPS)
 
-		assert.Equal(t, 100, int(testutil.ToFloat64(loomQueryCounters[0])), "Query count should be 100")
-		assert.Equal(t, 100, int(testutil.ToFloat64(loomQueryCounter)), "Total query count should be 100")
+		assert.Equal(t, 100, int(gather(t, "xloom_tasks_total", "loom", "0")), "Query count should be 100")
+		assert.Equal(t, 100, int(gather(t, "xloom_tasks_total")), "Total query count should be 100")
 
 		// 2. 测试暂停状态下的指标
 		Pause(0)
@@ -116,13 +116,13 @@ func TestLoom(t *testing.T) {
 
 		// 验证暂停时的指标
 		assert.Zero(t, FPS(0), "FPS should be 0 while paused")
-		assert.Zero(t, testutil.ToFloat64(loomFPSGauges[0]), "FPS should be 0 while paused")
+		assert.Zero(t, gather(t, "xloom_fps", "loom", "0"), "FPS should be 0 while paused")
 
 		assert.Zero(t, QPS(0), "QPS should be 0 while paused")
-		assert.Zero(t, testutil.ToFloat64(loomQPSGauges[0]), "QPS should be 0 while paused")
+		assert.Zero(t, gather(t, "xloom_qps", "loom", "0"), "QPS should be 0 while paused")
 
-		assert.Equal(t, 100, int(testutil.ToFloat64(loomQueryCounters[0])), "Query count should be 100")
-		assert.Equal(t, 100, int(testutil.ToFloat64(loomQueryCounter)), "Total query count should be 100")
+		assert.Equal(t, 100, int(gather(t, "xloom_tasks_total", "loom", "0")), "Query count should be 100")
+		assert.Equal(t, 100, int(gather(t, "xloom_tasks_total")), "Total query count should be 100")
 
 		// 3. 测试恢复后的指标
 		Resume(0)
@@ -132,13 +132,13 @@ func TestLoom(t *testing.T) {
 
 		// 验证恢复后的指标
 		assert.InDelta(t, expectedFPS, FPS(0), float64(expectedFPS)*0.3, "FPS should be around %d (±30%%)", expectedFPS)
-		assert.InDelta(t, expectedFPS, testutil.ToFloat64(loomFPSGauges[0]), float64(expectedFPS)*0.3, "FPS should be around %d (±30%%)", expectedFPS)
+		assert.InDelta(t, expectedFPS, gather(t, "xloom_fps", "loom", "0"), float64(expectedFPS)*0.3, "FPS should be around %d (±30%%)", expectedFPS)
 
 		assert.InDelta(t, expectedQPS, QPS(0), float64(expectedQPS)*0.3, "QPS should be around %d (±30%%)", expectedQPS)
-		assert.InDelta(t, expectedQPS, testutil.ToFloat64(loomQPSGauges[0]), float64(expectedQPS)*0.3, "QPS should be around %d (±30%%)", expectedQPS)
+		assert.InDelta(t, expectedQPS, gather(t, "xloom_qps", "loom", "0"), float64(expectedQPS)*0.3, "QPS should be around %d (±30%%)", expectedQPS)
 
-		assert.Equal(t, 200, int(testutil.ToFloat64(loomQueryCounters[0])), "Query count should be 200")
-		assert.Equal(t, 200, int(testutil.ToFloat64(loomQueryCounter)), "Total query count should be 200")
+		assert.Equal(t, 200, int(gather(t, "xloom_tasks_total", "loom", "0")), "Query count should be 200")
+		assert.Equal(t, 200, int(gather(t, "xloom_tasks_total")), "Total query count should be 200")
 
 		// 4. 测试无效的处理器ID
 		assert.Equal(t, 0, FPS(-1), "Invalid PID should return 0")
@@ -146,4 +146,49 @@ func TestLoom(t *testing.T) {
 		assert.Equal(t, 0, QPS(-1), "Invalid PID should return 0")
 		assert.Equal(t, 0, QPS(999), "Out of range PID should return 0")
 	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 2).Set(prefsStep, 10).Set(prefsQueue, 1000))
+
+		// 在线程之外并发调用 ID、Pause、Resume、FPS 及 QPS，配合 go test -race 检测数据竞争
+		var wg sync.WaitGroup
+		for i := range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 50 {
+					assert.Equal(t, -1, ID(), "线程之外的 goroutine 不应当映射至任何线程")
+					FPS(i % 2)
+					QPS(i % 2)
+					if i%2 == 0 {
+						Pause(i % 2)
+						Resume(i % 2)
+					}
+				}
+			}()
+		}
+		for range 50 {
+			RunIn(func() { ID() }, 1)
+		}
+		wg.Wait()
+
+		done := make(chan int)
+		RunIn(func() { done <- ID() }, 1)
+		assert.Equal(t, 1, <-done, "线程中调用 ID 应当返回线程 ID")
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		assert.Equal(t, -1, LoomFrom(context.Background()), "未携带线程 ID 的上下文应当返回 -1")
+		assert.Equal(t, -1, LoomFrom(nil), "空的上下文应当返回 -1")
+		assert.Equal(t, 1, LoomFrom(NewContext(context.Background(), 1)), "应当返回上下文携带的线程 ID")
+
+		g, _ := NewGroup(context.Background())
+		lid := make(chan int, 1)
+		g.GoIn(1, func(ctx context.Context) error {
+			lid <- LoomFrom(ctx)
+			return nil
+		})
+		assert.NoError(t, g.Wait())
+		assert.Equal(t, 1, <-lid, "GoIn 传入的上下文应当携带线程 ID")
+	})
 }
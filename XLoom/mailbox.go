@@ -0,0 +1,245 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/eframework-org/GO.UTIL/XLog"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// mailboxIID 为信箱自增标识。
+var mailboxIID int64
+
+// MailboxOption 定义了信箱的可选参数。
+type MailboxOption func(*mailboxOption)
+
+// mailboxOption 定义了信箱的参数。
+type mailboxOption struct {
+	capacity int    // 信箱容量，0 表示使用线程池的 Loom/Queue
+	name     string // 信箱名称，用于度量的 mailbox 标签
+	pool     *Pool  // 信箱所属的线程池，为 nil 时使用默认线程池
+}
+
+// WithCapacity 设置信箱的容量。
+// capacity 为信箱最多缓存的消息数量，小于等于 0 时使用线程池的 Loom/Queue。
+func WithCapacity(capacity int) MailboxOption {
+	return func(option *mailboxOption) { option.capacity = capacity }
+}
+
+// WithName 设置信箱的名称，用于度量的 mailbox 标签，同名信箱的度量将被合并。
+func WithName(name string) MailboxOption {
+	return func(option *mailboxOption) { option.name = name }
+}
+
+// WithMailboxPool 设置信箱所属的线程池，默认使用默认线程池。
+func WithMailboxPool(pool *Pool) MailboxOption {
+	return func(option *mailboxOption) { option.pool = pool }
+}
+
+// mailboxStat 定义了信箱的度量状态，用于在采集时读取不同类型的信箱。
+type mailboxStat interface {
+	stat() (loom int, name string, depth int, delivered, rejected uint64)
+}
+
+// Mailbox 定义了绑定至线程的类型化信箱，用于在热点路径上替代 RunIn 以避免为每条消息分配闭包。
+// 消息在所属线程的每一帧中批量投递，单帧仅处理帧开始时已存在的消息，新到达的消息在下一帧处理。
+// 所属线程退役时信箱随帧回调迁移至目标线程，未处理的消息将保留。
+type Mailbox[T any] struct {
+	name      string        // 信箱名称
+	ch        chan T        // 消息队列
+	handler   func(msg T)   // 消息处理函数
+	hook      *updateHook   // 批量投递消息的帧回调
+	unsub     func()        // 注销信箱度量
+	closed    atomic.Bool   // 是否已关闭，关闭后不再接收新的消息
+	delivered atomic.Uint64 // 投递总数统计
+	rejected  atomic.Uint64 // 拒绝总数统计
+}
+
+// NewMailbox 创建绑定至指定线程的信箱。
+// loomID 为所属线程 ID，handler 为消息处理函数，在所属线程中调用，发生的 panic 不影响其他消息的处理。
+// options 为可选参数，如 WithCapacity、WithName、WithMailboxPool 等。
+// 返回创建的信箱，如果参数无效则返回 nil。
+func NewMailbox[T any](loomID int, handler func(msg T), options ...MailboxOption) *Mailbox[T] {
+	if handler == nil {
+		XLog.Critical("XLoom.NewMailbox: handler can not be nil.")
+		return nil
+	}
+	var option mailboxOption
+	for _, opt := range options {
+		if opt != nil {
+			opt(&option)
+		}
+	}
+	pool := option.pool
+	if pool == nil {
+		pool = defaultPool
+	}
+	capacity := option.capacity
+	if capacity <= 0 {
+		capacity = pool.queue
+	}
+	id := atomic.AddInt64(&mailboxIID, 1)
+	if option.name == "" {
+		option.name = fmt.Sprint(id)
+	}
+
+	mb := &Mailbox[T]{name: option.name, ch: make(chan T, capacity), handler: handler}
+	mb.hook = pool.newUpdate("NewMailbox", false, loomID, mb.deliver)
+	if mb.hook == nil {
+		return nil
+	}
+	mb.unsub = pool.mailboxes.add(mb)
+	return mb
+}
+
+// deliver 在所属线程中处理帧开始时已存在的消息。
+func (mb *Mailbox[T]) deliver(delta int) {
+	for n := len(mb.ch); n > 0; n-- {
+		var msg T
+		select {
+		case msg = <-mb.ch:
+		default:
+			return
+		}
+		func() {
+			defer XLog.Caught(false)
+			mb.handler(msg)
+		}()
+		mb.delivered.Add(1)
+	}
+}
+
+// stat 实现 mailboxStat 接口。
+func (mb *Mailbox[T]) stat() (int, string, int, uint64, uint64) {
+	return mb.hook.loom().id, mb.name, len(mb.ch), mb.delivered.Load(), mb.rejected.Load()
+}
+
+// Post 投递消息至信箱，可在任意 goroutine 中调用，不会阻塞调用方。
+// 返回是否投递成功，信箱已满或已关闭时返回 false。
+func (mb *Mailbox[T]) Post(msg T) bool {
+	if mb.closed.Load() {
+		mb.rejected.Add(1)
+		XLog.Critical("XLoom.Mailbox.Post: mailbox %v is closed, post was rejected.", mb.name)
+		return false
+	}
+	select {
+	case mb.ch <- msg:
+		return true
+	default:
+		mb.rejected.Add(1)
+		XLog.Critical("XLoom.Mailbox.Post: too many messages in mailbox %v.", mb.name)
+		return false
+	}
+}
+
+// Send 投递消息至信箱，信箱已满时阻塞直至存在空位或上下文取消，请勿在所属线程中调用以免阻塞帧更新。
+// ctx 为投递的上下文，为 nil 时使用 context.Background。
+// 返回投递过程中发生的错误，信箱已关闭时返回 ErrRejected，上下文取消时返回上下文的错误。
+func (mb *Mailbox[T]) Send(ctx context.Context, msg T) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if mb.closed.Load() {
+		mb.rejected.Add(1)
+		return ErrRejected
+	}
+	select {
+	case mb.ch <- msg:
+		return nil
+	case <-ctx.Done():
+		mb.rejected.Add(1)
+		return ctx.Err()
+	}
+}
+
+// C 返回信箱的发送通道，用于在 select 语句中投递消息，如：
+//
+//	select {
+//	case mb.C() <- msg:
+//	case <-ctx.Done():
+//	}
+//
+// 通过通道投递的消息不检查信箱是否已关闭，亦不计入拒绝总数。
+func (mb *Mailbox[T]) C() chan<- T { return mb.ch }
+
+// Len 返回信箱中等待处理的消息数量。
+func (mb *Mailbox[T]) Len() int { return len(mb.ch) }
+
+// Cap 返回信箱的容量。
+func (mb *Mailbox[T]) Cap() int { return cap(mb.ch) }
+
+// Loom 返回信箱当前所属的线程 ID，所属线程退役后返回迁移的目标线程 ID。
+func (mb *Mailbox[T]) Loom() int { return mb.hook.loom().id }
+
+// Close 关闭信箱，信箱中未处理的消息将被丢弃，可在任意 goroutine 中调用，重复调用无副作用。
+func (mb *Mailbox[T]) Close() {
+	if !mb.closed.CompareAndSwap(false, true) {
+		return
+	}
+	mb.hook.detach()
+	mb.unsub()
+}
+
+// mailboxCollector 定义了信箱状态的度量采集器，在采集时读取线程池中各信箱的状态，同名信箱的度量将被合并。
+type mailboxCollector struct {
+	pool          *Pool            // 采集的线程池
+	depthDesc     *prometheus.Desc // 队列深度度量描述
+	deliveredDesc *prometheus.Desc // 投递总数度量描述
+	rejectedDesc  *prometheus.Desc // 拒绝总数度量描述
+}
+
+// newMailboxCollector 创建信箱状态的度量采集器。
+func newMailboxCollector(p *Pool, prefix string) *mailboxCollector {
+	return &mailboxCollector{
+		pool:          p,
+		depthDesc:     prometheus.NewDesc(prefix+"_mailbox_depth", "Number of messages waiting in mailbox by loom and mailbox.", []string{"loom", "mailbox"}, nil),
+		deliveredDesc: prometheus.NewDesc(prefix+"_mailbox_messages_total", "Total number of messages delivered by loom and mailbox.", []string{"loom", "mailbox"}, nil),
+		rejectedDesc:  prometheus.NewDesc(prefix+"_mailbox_rejected_total", "Total number of messages rejected by loom and mailbox.", []string{"loom", "mailbox"}, nil),
+	}
+}
+
+// Describe 实现 prometheus.Collector 接口。
+func (c *mailboxCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.depthDesc
+	ch <- c.deliveredDesc
+	ch <- c.rejectedDesc
+}
+
+// Collect 实现 prometheus.Collector 接口。
+func (c *mailboxCollector) Collect(ch chan<- prometheus.Metric) {
+	type key struct {
+		loom string
+		name string
+	}
+	type value struct {
+		depth               int
+		delivered, rejected uint64
+	}
+	keys := []key{}
+	values := map[key]*value{}
+	c.pool.mailboxes.each(func(mb mailboxStat) {
+		lid, name, depth, delivered, rejected := mb.stat()
+		k := key{loom: fmt.Sprint(lid), name: name}
+		v := values[k]
+		if v == nil {
+			v = &value{}
+			values[k] = v
+			keys = append(keys, k)
+		}
+		v.depth += depth
+		v.delivered += delivered
+		v.rejected += rejected
+	})
+	for _, k := range keys {
+		v := values[k]
+		ch <- prometheus.MustNewConstMetric(c.depthDesc, prometheus.GaugeValue, float64(v.depth), k.loom, k.name)
+		ch <- prometheus.MustNewConstMetric(c.deliveredDesc, prometheus.CounterValue, float64(v.delivered), k.loom, k.name)
+		ch <- prometheus.MustNewConstMetric(c.rejectedDesc, prometheus.CounterValue, float64(v.rejected), k.loom, k.name)
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/eframework-org/GO.UTIL/XPrefs"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMailbox(t *testing.T) {
+	defer setup(XPrefs.Asset())
+
+	t.Run("Deliver", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 2).Set(prefsStep, 10).Set(prefsQueue, 1000))
+
+		var mu sync.Mutex
+		var msgs []int
+		var looms []int
+		mb := NewMailbox(1, func(msg int) {
+			mu.Lock()
+			defer mu.Unlock()
+			msgs = append(msgs, msg)
+			looms = append(looms, ID())
+		})
+		defer mb.Close()
+		assert.Equal(t, 1, mb.Loom())
+		assert.Equal(t, 1000, mb.Cap(), "默认容量应当为 Loom/Queue")
+
+		for i := range 3 {
+			assert.True(t, mb.Post(i))
+		}
+		assert.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(msgs) == 3
+		}, time.Second, time.Millisecond*10, "消息应当被投递")
+		assert.Equal(t, []int{0, 1, 2}, msgs, "消息应当按照投递顺序处理")
+		assert.Equal(t, []int{1, 1, 1}, looms, "消息应当在所属线程中处理")
+		assert.Equal(t, 0, mb.Len())
+	})
+
+	t.Run("Batch", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 1).Set(prefsStep, 10).Set(prefsQueue, 1000))
+
+		var frame atomic.Int32
+		unsub := OnLateUpdate(0, func(int) { frame.Add(1) })
+		defer unsub()
+
+		frames := map[string]int32{}
+		done := make(chan struct{})
+		var mb *Mailbox[string]
+		mb = NewMailbox(0, func(msg string) {
+			frames[msg] = frame.Load()
+			if msg == "a" {
+				mb.Post("d") // 处理期间到达的消息应当在下一帧处理
+			}
+			if msg == "d" {
+				close(done)
+			}
+		})
+		defer mb.Close()
+
+		Pause(0)
+		time.Sleep(time.Millisecond * 50)
+		for _, msg := range []string{"a", "b", "c"} {
+			mb.Post(msg)
+		}
+		Resume(0)
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("消息投递超时")
+		}
+		assert.Equal(t, frames["a"], frames["b"], "同一帧内的消息应当批量处理")
+		assert.Equal(t, frames["a"], frames["c"], "同一帧内的消息应当批量处理")
+		assert.Greater(t, frames["d"], frames["a"], "处理期间到达的消息应当在下一帧处理")
+	})
+
+	t.Run("Capacity", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 1).Set(prefsStep, 10).Set(prefsQueue, 1000))
+
+		var delivered atomic.Int32
+		mb := NewMailbox(0, func(msg int) {
+			if msg == 1 {
+				panic("boom")
+			}
+			delivered.Add(1)
+		}, WithCapacity(2), WithName("capacity"))
+		defer mb.Close()
+
+		Pause(0)
+		time.Sleep(time.Millisecond * 50)
+		assert.True(t, mb.Post(1))
+		select {
+		case mb.C() <- 2:
+		default:
+			t.Fatal("信箱未满时应当能够通过通道投递")
+		}
+		assert.False(t, mb.Post(3), "信箱已满时应当投递失败")
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+		defer cancel()
+		assert.ErrorIs(t, mb.Send(ctx, 4), context.DeadlineExceeded, "信箱已满时应当阻塞直至上下文取消")
+
+		assert.Equal(t, 2.0, gather(t, "xloom_mailbox_depth", "loom", "0", "mailbox", "capacity"), "等待处理的消息数量应当为 2")
+		assert.Equal(t, 2.0, gather(t, "xloom_mailbox_rejected_total", "mailbox", "capacity"), "拒绝总数应当为 2")
+
+		Resume(0)
+		assert.Eventually(t, func() bool { return delivered.Load() == 1 }, time.Second, time.Millisecond*10,
+			"单条消息发生异常不应当影响其他消息的处理")
+		assert.Eventually(t, func() bool {
+			return gather(t, "xloom_mailbox_messages_total", "mailbox", "capacity") == 2
+		}, time.Second, time.Millisecond*10, "投递总数应当为 2")
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 1).Set(prefsStep, 10).Set(prefsQueue, 1000))
+
+		var delivered atomic.Int32
+		mb := NewMailbox(0, func(int) { delivered.Add(1) }, WithName("close"))
+		Pause(0)
+		time.Sleep(time.Millisecond * 50)
+		mb.Post(1)
+		mb.Close()
+		mb.Close()
+		Resume(0)
+		time.Sleep(time.Millisecond * 50)
+		assert.Equal(t, int32(0), delivered.Load(), "关闭后未处理的消息应当被丢弃")
+		assert.False(t, mb.Post(2), "关闭后应当投递失败")
+		assert.ErrorIs(t, mb.Send(context.Background(), 2), ErrRejected, "关闭后应当投递失败")
+		_, found := gatherFrom(t, prometheus.DefaultGatherer, "xloom_mailbox_depth", "mailbox", "close")
+		assert.False(t, found, "关闭后不应当导出信箱的度量")
+	})
+
+	t.Run("Resize", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 2).Set(prefsStep, 10).Set(prefsQueue, 1000))
+
+		received := make(chan int, 1)
+		mb := NewMailbox(1, func(int) { received <- ID() })
+		defer mb.Close()
+		Resize(1)
+		assert.Equal(t, 0, mb.Loom(), "线程退役后信箱应当迁移至目标线程")
+		mb.Post(1)
+		select {
+		case lid := <-received:
+			assert.Equal(t, 0, lid, "消息应当在目标线程中处理")
+		case <-time.After(time.Second):
+			t.Fatal("消息投递超时")
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 1).Set(prefsStep, 10).Set(prefsQueue, 1000))
+
+		assert.Nil(t, NewMailbox[int](0, nil), "空的处理函数应当创建失败")
+		assert.Nil(t, NewMailbox(-1, func(int) {}), "负数的线程 ID 应当创建失败")
+		assert.Nil(t, NewMailbox(999, func(int) {}), "无效的线程 ID 应当创建失败")
+	})
+}
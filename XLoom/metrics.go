@@ -0,0 +1,275 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync/atomic"
+
+	"github.com/eframework-org/GO.UTIL/XLog"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// poolMetrics 定义了线程池的度量集合，度量名称均以线程池的度量前缀开头。
+type poolMetrics struct {
+	prefix        string                   // 度量前缀
+	looms         *loomCollector           // 线程状态度量采集器
+	slow          *prometheus.CounterVec   // 线程慢执行总数度量
+	duration      *prometheus.HistogramVec // 线程执行耗时度量
+	latency       *prometheus.HistogramVec // 线程任务排队时延度量
+	workers       *workerCollector         // 工作池状态度量采集器
+	workerLatency prometheus.Histogram     // 工作池任务排队时延度量
+	mailboxes     *mailboxCollector        // 信箱状态度量采集器
+}
+
+// newPoolMetrics 创建线程池的度量集合。
+// prefix 为度量前缀，如 xloom，度量名称为 xloom_fps、xloom_latency_seconds 等。
+func newPoolMetrics(p *Pool, prefix string) *poolMetrics {
+	buckets := []float64{.0001, .0005, .001, .005, .01, .05, .1, .5, 1, 5}
+	return &poolMetrics{
+		prefix: prefix,
+		looms:  newLoomCollector(p, prefix),
+		slow: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: prefix + "_slow_total",
+			Help: "Total number of executions exceeding the budget by loom and kind.",
+		}, []string{"loom", "kind"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    prefix + "_duration_seconds",
+			Help:    "Execution duration of tasks, timer callbacks and frames by loom and kind.",
+			Buckets: buckets,
+		}, []string{"loom", "kind"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    prefix + "_latency_seconds",
+			Help:    "Queueing latency from RunIn to execution by loom and lane.",
+			Buckets: buckets,
+		}, []string{"loom", "lane"}),
+		workers: newWorkerCollector(p, prefix),
+		workerLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    prefix + "_worker_latency_seconds",
+			Help:    "Queueing latency from Offload to execution in worker pool.",
+			Buckets: buckets,
+		}),
+		mailboxes: newMailboxCollector(p, prefix),
+	}
+}
+
+// collectors 返回需要注册的度量采集器。
+func (m *poolMetrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{m.looms, m.slow, m.duration, m.latency, m.workers, m.workerLatency, m.mailboxes}
+}
+
+// loomCollector 定义了线程状态的度量采集器，在采集时读取线程池中各线程的状态。
+type loomCollector struct {
+	pool      *Pool            // 采集的线程池
+	fpsDesc   *prometheus.Desc // 刷新帧率度量描述
+	qpsDesc   *prometheus.Desc // 处理速率度量描述
+	taskDesc  *prometheus.Desc // 处理总数度量描述
+	queueDesc *prometheus.Desc // 队列深度度量描述
+	timerDesc *prometheus.Desc // 定时器数量度量描述
+}
+
+// newLoomCollector 创建线程状态的度量采集器。
+func newLoomCollector(p *Pool, prefix string) *loomCollector {
+	return &loomCollector{
+		pool:      p,
+		fpsDesc:   prometheus.NewDesc(prefix+"_fps", "Frames per second by loom.", []string{"loom"}, nil),
+		qpsDesc:   prometheus.NewDesc(prefix+"_qps", "Queries per second by loom.", []string{"loom"}, nil),
+		taskDesc:  prometheus.NewDesc(prefix+"_tasks_total", "Total number of tasks processed by loom and lane.", []string{"loom", "lane"}, nil),
+		queueDesc: prometheus.NewDesc(prefix+"_queue_depth", "Number of tasks waiting in queue by loom and lane.", []string{"loom", "lane"}, nil),
+		timerDesc: prometheus.NewDesc(prefix+"_timers", "Number of pending timers by loom.", []string{"loom"}, nil),
+	}
+}
+
+// Describe 实现 prometheus.Collector 接口。
+func (c *loomCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.fpsDesc
+	ch <- c.qpsDesc
+	ch <- c.taskDesc
+	ch <- c.queueDesc
+	ch <- c.timerDesc
+}
+
+// Collect 实现 prometheus.Collector 接口。
+func (c *loomCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, l := range c.pool.looms() {
+		lid := fmt.Sprint(l.id)
+		ch <- prometheus.MustNewConstMetric(c.fpsDesc, prometheus.GaugeValue, l.loadFPS(), lid)
+		ch <- prometheus.MustNewConstMetric(c.qpsDesc, prometheus.GaugeValue, l.loadQPS(), lid)
+		for p := range priorityCount {
+			ch <- prometheus.MustNewConstMetric(c.taskDesc, prometheus.CounterValue, float64(l.lanes[p].Load()), lid, p.String())
+			ch <- prometheus.MustNewConstMetric(c.queueDesc, prometheus.GaugeValue, float64(len(l.task[p])), lid, p.String())
+		}
+		ch <- prometheus.MustNewConstMetric(c.timerDesc, prometheus.GaugeValue, float64(l.timers.count.Load()), lid)
+	}
+}
+
+// legacyCollector 定义了旧版度量的采集器，用于兼容按线程命名的度量，如 xloom_fps_0。
+// 按线程命名的度量名称随线程数量变化，因此 Describe 仅声明名称固定的处理总数度量，使采集器可以从注册器中注销。
+// 注销后通过 disabled 停止采集，避免注册器未能注销时导出重复的度量，每次注册均创建新的实例。
+type legacyCollector struct {
+	pool      *Pool            // 采集的线程池
+	prefix    string           // 度量前缀
+	queryDesc *prometheus.Desc // 所有线程处理总数度量描述
+	disabled  atomic.Bool      // 是否已停止采集
+}
+
+// newLegacyCollector 创建旧版度量的采集器。
+func newLegacyCollector(p *Pool, prefix string) *legacyCollector {
+	return &legacyCollector{
+		pool:      p,
+		prefix:    prefix,
+		queryDesc: prometheus.NewDesc(prefix+"_query_total", "Total number of queries processed by all looms.", nil, nil),
+	}
+}
+
+// Describe 实现 prometheus.Collector 接口。
+func (c *legacyCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.queryDesc
+}
+
+// Collect 实现 prometheus.Collector 接口。
+func (c *legacyCollector) Collect(ch chan<- prometheus.Metric) {
+	if c.disabled.Load() {
+		return
+	}
+	for _, l := range c.pool.looms() {
+		ch <- prometheus.MustNewConstMetric(prometheus.NewDesc(fmt.Sprintf("%v_fps_%v", c.prefix, l.id),
+			fmt.Sprintf("Frames per second for loom %v.", l.id), nil, nil), prometheus.GaugeValue, l.loadFPS())
+		ch <- prometheus.MustNewConstMetric(prometheus.NewDesc(fmt.Sprintf("%v_qps_%v", c.prefix, l.id),
+			fmt.Sprintf("Queries per second for loom %v.", l.id), nil, nil), prometheus.GaugeValue, l.loadQPS())
+		ch <- prometheus.MustNewConstMetric(prometheus.NewDesc(fmt.Sprintf("%v_query_total_%v", c.prefix, l.id),
+			fmt.Sprintf("Total number of queries processed by loom %v.", l.id), nil, nil), prometheus.CounterValue, float64(l.queries.Load()))
+	}
+	ch <- prometheus.MustNewConstMetric(c.queryDesc, prometheus.CounterValue, float64(c.pool.metricsQuery.Load()))
+}
+
+// loadFPS 读取线程的刷新帧率。
+func (l *loom) loadFPS() float64 { return math.Float64frombits(l.fps.Load()) }
+
+// loadQPS 读取线程的处理速率。
+func (l *loom) loadQPS() float64 { return math.Float64frombits(l.qps.Load()) }
+
+// storeRate 更新线程的刷新帧率及处理速率。
+func (l *loom) storeRate(fps, qps float64) {
+	l.fps.Store(math.Float64bits(fps))
+	l.qps.Store(math.Float64bits(qps))
+}
+
+// register 将度量采集器注册至注册器，已注册的相同采集器将被忽略。
+func register(reg prometheus.Registerer, cs []prometheus.Collector) error {
+	var errs []error
+	for _, c := range cs {
+		if err := reg.Register(c); err != nil {
+			are := prometheus.AlreadyRegisteredError{}
+			if errors.As(err, &are) && are.ExistingCollector == c {
+				continue
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// unregister 将度量采集器从注册器中注销。
+func unregister(reg prometheus.Registerer, cs []prometheus.Collector) {
+	for _, c := range cs {
+		reg.Unregister(c)
+	}
+}
+
+// attachLegacy 将新的旧版度量采集器注册至注册器，调用时需持有 metricsMu。
+func (p *Pool) attachLegacy(reg prometheus.Registerer) error {
+	c := newLegacyCollector(p, p.metrics.prefix)
+	if err := reg.Register(c); err != nil {
+		return err
+	}
+	p.legacyMetrics = c
+	return nil
+}
+
+// detachLegacy 将当前旧版度量采集器从注册器中注销并停止采集，调用时需持有 metricsMu。
+func (p *Pool) detachLegacy() {
+	if p.legacyMetrics != nil {
+		if p.metricsReg != nil {
+			p.metricsReg.Unregister(p.legacyMetrics)
+		}
+		p.legacyMetrics.disabled.Store(true)
+		p.legacyMetrics = nil
+	}
+}
+
+// setupMetrics 初始化线程池的度量，重置所有度量的数值并按需注册旧版的度量名称。
+// prefix 为度量前缀，与当前的度量前缀不同时将注销原有的度量并重新创建。
+func (p *Pool) setupMetrics(legacy bool, prefix string) {
+	p.metricsMu.Lock()
+	defer p.metricsMu.Unlock()
+
+	if p.metrics == nil || p.metrics.prefix != prefix {
+		if p.metrics != nil && p.metricsReg != nil {
+			unregister(p.metricsReg, p.metrics.collectors())
+		}
+		p.detachLegacy()
+		p.metrics = newPoolMetrics(p, prefix)
+	}
+
+	p.metricsQuery.Store(0)
+	p.metrics.slow.Reset()
+	p.metrics.duration.Reset()
+	p.metrics.latency.Reset()
+
+	p.metricsLegacy = legacy
+	if !legacy {
+		p.detachLegacy()
+	}
+	if p.metricsReg == nil {
+		return
+	}
+	err := register(p.metricsReg, p.metrics.collectors())
+	if legacy && p.legacyMetrics == nil {
+		err = errors.Join(err, p.attachLegacy(p.metricsReg))
+	}
+	if err != nil {
+		XLog.Error("XLoom.Metrics: register metrics failed: %v", err)
+	}
+}
+
+// closeMetrics 将线程池的度量从注册器中注销。
+func (p *Pool) closeMetrics() {
+	p.metricsMu.Lock()
+	defer p.metricsMu.Unlock()
+
+	if p.metricsReg != nil {
+		unregister(p.metricsReg, p.metrics.collectors())
+	}
+	p.detachLegacy()
+}
+
+// SetRegisterer 设置默认线程池度量的注册器，参见 Pool.SetRegisterer。
+func SetRegisterer(reg prometheus.Registerer) error { return defaultPool.SetRegisterer(reg) }
+
+// SetRegisterer 设置线程池度量的注册器，默认使用 prometheus.DefaultRegisterer。
+// reg 为新的注册器，为 nil 时注销所有度量。
+// 度量将从原注册器中注销并注册至新的注册器，返回注册过程中发生的错误。
+func (p *Pool) SetRegisterer(reg prometheus.Registerer) error {
+	p.metricsMu.Lock()
+	defer p.metricsMu.Unlock()
+
+	cs := p.metrics.collectors()
+	if p.metricsReg != nil {
+		unregister(p.metricsReg, cs)
+	}
+	p.detachLegacy()
+	p.metricsReg = reg
+	if reg == nil {
+		return nil
+	}
+	err := register(reg, cs)
+	if p.metricsLegacy {
+		err = errors.Join(err, p.attachLegacy(reg))
+	}
+	return err
+}
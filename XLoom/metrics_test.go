@@ -0,0 +1,156 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eframework-org/GO.UTIL/XPrefs"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+// gatherFrom 采集指定名称的度量，返回所有匹配标签的度量数值之和及是否存在匹配的度量。
+// pairs 为标签名称及标签数值的键值对，直方图返回其样本数量。
+func gatherFrom(t *testing.T, g prometheus.Gatherer, name string, pairs ...string) (float64, bool) {
+	families, err := g.Gather()
+	assert.NoError(t, err)
+	sum := 0.0
+	found := false
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	metric:
+		for _, m := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range m.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			for i := 0; i+1 < len(pairs); i += 2 {
+				if labels[pairs[i]] != pairs[i+1] {
+					continue metric
+				}
+			}
+			found = true
+			switch {
+			case m.Gauge != nil:
+				sum += m.Gauge.GetValue()
+			case m.Counter != nil:
+				sum += m.Counter.GetValue()
+			case m.Histogram != nil:
+				sum += float64(m.Histogram.GetSampleCount())
+			}
+		}
+	}
+	return sum, found
+}
+
+// gather 从默认的注册器中采集指定名称的度量。
+func gather(t *testing.T, name string, pairs ...string) float64 {
+	value, _ := gatherFrom(t, prometheus.DefaultGatherer, name, pairs...)
+	return value
+}
+
+func TestMetrics(t *testing.T) {
+	defer setup(XPrefs.Asset())
+
+	t.Run("Setup", func(t *testing.T) {
+		// 重复初始化不应当因重复注册而发生异常
+		assert.NotPanics(t, func() {
+			for range 3 {
+				setup(XPrefs.New().Set(prefsCount, 2).Set(prefsStep, 10).Set(prefsQueue, 1000))
+			}
+		})
+		_, found := gatherFrom(t, prometheus.DefaultGatherer, "xloom_fps", "loom", "1")
+		assert.True(t, found, "应当导出带有 loom 标签的度量")
+	})
+
+	t.Run("Registerer", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 2).Set(prefsStep, 10).Set(prefsQueue, 1000))
+
+		reg := prometheus.NewRegistry()
+		assert.NoError(t, SetRegisterer(reg))
+		defer SetRegisterer(prometheus.DefaultRegisterer)
+
+		_, found := gatherFrom(t, reg, "xloom_qps", "loom", "0")
+		assert.True(t, found, "度量应当注册至新的注册器")
+		_, found = gatherFrom(t, prometheus.DefaultGatherer, "xloom_qps", "loom", "0")
+		assert.False(t, found, "度量应当从原注册器中注销")
+
+		// 初始化后度量仍注册在新的注册器中
+		setup(XPrefs.New().Set(prefsCount, 1).Set(prefsStep, 10).Set(prefsQueue, 1000))
+		_, found = gatherFrom(t, reg, "xloom_qps", "loom", "0")
+		assert.True(t, found, "初始化后度量应当保留在新的注册器中")
+		_, found = gatherFrom(t, reg, "xloom_qps", "loom", "1")
+		assert.False(t, found, "初始化后不应当导出已关闭线程的度量")
+
+		assert.NoError(t, SetRegisterer(nil))
+		_, found = gatherFrom(t, reg, "xloom_qps", "loom", "0")
+		assert.False(t, found, "设置为 nil 后应当注销所有度量")
+	})
+
+	t.Run("Queue", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 1).Set(prefsStep, 10).Set(prefsQueue, 1000))
+
+		Pause(0)
+		time.Sleep(time.Millisecond * 50)
+
+		var wg sync.WaitGroup
+		wg.Add(3)
+		for range 3 {
+			RunInWith(func() {
+				time.Sleep(time.Millisecond * 10)
+				wg.Done()
+			}, WithPriority(PriorityLow))
+		}
+		SetTimeout(func() {}, 10000, 0)
+		SetInterval(func() {}, 10000, 0)
+
+		assert.Equal(t, 3.0, gather(t, "xloom_queue_depth", "loom", "0", "lane", "low"), "低优先级队列深度应当为 3")
+		assert.Equal(t, 0.0, gather(t, "xloom_queue_depth", "loom", "0", "lane", "high"), "高优先级队列深度应当为 0")
+		assert.Equal(t, 2.0, gather(t, "xloom_timers", "loom", "0"), "定时器数量应当为 2")
+
+		Resume(0)
+		wg.Wait()
+		assert.Equal(t, 0.0, gather(t, "xloom_queue_depth", "loom", "0"), "任务执行后队列深度应当为 0")
+		assert.Equal(t, 3.0, gather(t, "xloom_latency_seconds", "loom", "0", "lane", "low"), "应当统计每个任务的排队时延")
+	})
+
+	t.Run("Legacy", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 2).Set(prefsStep, 10).Set(prefsQueue, 1000).Set(prefsLegacyMetrics, true))
+
+		done := make(chan struct{})
+		RunIn(func() { close(done) }, 1)
+		<-done
+
+		_, found := gatherFrom(t, prometheus.DefaultGatherer, "xloom_fps_1")
+		assert.True(t, found, "应当导出旧版的帧率度量")
+		_, found = gatherFrom(t, prometheus.DefaultGatherer, "xloom_qps_1")
+		assert.True(t, found, "应当导出旧版的处理速率度量")
+		assert.Equal(t, 1.0, gather(t, "xloom_query_total_1"), "旧版的线程处理总数应当为 1")
+		assert.Equal(t, 1.0, gather(t, "xloom_query_total"), "旧版的所有线程处理总数应当为 1")
+
+		reg := prometheus.NewRegistry()
+		assert.NoError(t, SetRegisterer(reg))
+		value, _ := gatherFrom(t, reg, "xloom_query_total")
+		assert.Equal(t, 1.0, value, "旧版的度量应当注册至新的注册器")
+		assert.NoError(t, prometheus.DefaultRegisterer.Register(newLegacyCollector(defaultPool, "xloom")), "旧版的度量应当从原注册器中注销")
+		prometheus.DefaultRegisterer.Unregister(newLegacyCollector(defaultPool, "xloom"))
+		assert.NoError(t, SetRegisterer(prometheus.DefaultRegisterer))
+
+		setup(XPrefs.New().Set(prefsCount, 2).Set(prefsStep, 10).Set(prefsQueue, 1000).Set(prefsLegacyMetrics, true).Set(prefsMetricsPrefix, "xloom_legacy"))
+		assert.NoError(t, prometheus.DefaultRegisterer.Register(newLegacyCollector(defaultPool, "xloom")), "修改度量前缀后应当注销原有的旧版度量")
+		prometheus.DefaultRegisterer.Unregister(newLegacyCollector(defaultPool, "xloom"))
+
+		setup(XPrefs.New().Set(prefsCount, 2).Set(prefsStep, 10).Set(prefsQueue, 1000))
+		_, found = gatherFrom(t, prometheus.DefaultGatherer, "xloom_fps_1")
+		assert.False(t, found, "关闭后不应当导出旧版的度量")
+		_, found = gatherFrom(t, prometheus.DefaultGatherer, "xloom_legacy_query_total")
+		assert.False(t, found, "关闭后应当注销旧版的度量")
+	})
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sync"
+	"sync/atomic"
+
+	"github.com/eframework-org/GO.UTIL/XLog"
+	"github.com/eframework-org/GO.UTIL/XPrefs"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metricsPrefixPattern 为度量前缀的格式，需满足 Prometheus 度量名称的规范。
+var metricsPrefixPattern = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// defaultPool 为默认线程池，包级别的函数均在该线程池中执行。
+var defaultPool = &Pool{metricsReg: prometheus.DefaultRegisterer}
+
+// Pool 定义了线程池，拥有独立的线程、任务队列、定时器、工作池、度量及生命周期。
+// 包级别的函数均委托至默认线程池，库可以通过 New 创建独立的线程池以避免与业务相互影响。
+type Pool struct {
+	initMu       sync.Mutex                 // 初始化互斥锁，用于保护初始化、扩缩容及关闭过程
+	list         atomic.Pointer[[]*loom]    // 线程列表，扩缩容时整体替换
+	closed       bool                       // 是否已关闭，关闭后不再接收新的任务
+	step         int                        // 更新步长，表示线程每帧的刷新间隔（毫秒）
+	queue        int                        // 队列大小，表示每个线程每个优先级的任务队列容量
+	starve       int                        // 饥饿阈值，非空通道被连续跳过的次数达到该值时优先处理
+	drainTimeout int                        // 排空超时，表示退出时执行剩余任务的最长时间（毫秒）
+	drainTimer   string                     // 排空定时器处理方式，表示退出时剩余超时调用的处理方式
+	budget       int                        // 执行预算，表示任务、定时器回调及帧更新的最长执行时间（毫秒），0 表示不检测
+	lockThread   bool                       // 是否将每个线程锁定至独立的系统线程
+	affinity     [][]int                    // 各线程绑定的 CPU 集合，线程 i 使用第 i % len(affinity) 个集合
+	workers      atomic.Pointer[workerPool] // 当前的工作池，初始化时整体替换
+
+	watchdogStop chan struct{}                          // 看门狗退出信号
+	slowHooks    hookList[func(SlowReport)]             // 慢执行回调列表
+	drainHooks   hookList[func(DrainReport)]            // 排空回调列表
+	resizeHooks  hookList[func(oldCount, newCount int)] // 扩缩容回调列表
+	mailboxes    hookList[mailboxStat]                  // 信箱列表，用于采集信箱的度量
+
+	keyBoxes   map[any]*keyBox // 键值信箱映射表，仅保存存在待执行任务的信箱
+	keyBoxesMu sync.Mutex      // 键值信箱互斥锁，用于保护信箱映射表及信箱状态
+
+	metricsMu     sync.Mutex            // 度量互斥锁，用于保护度量的注册过程
+	metricsReg    prometheus.Registerer // 度量注册器，为 nil 时不注册度量
+	metricsLegacy bool                  // 是否注册旧版的度量名称
+	metricsQuery  atomic.Uint64         // 所有线程处理总数，用于旧版的 query_total 度量
+	metrics       *poolMetrics          // 当前的度量集合，度量前缀变化时整体替换
+	legacyMetrics *legacyCollector      // 当前注册的旧版度量采集器，未注册时为 nil
+}
+
+func init() {
+	positive := []float64{1, math.Inf(1)}
+	nonNegative := []float64{0, math.Inf(1)}
+	XPrefs.RegisterSchema("Loom/", map[string]*XPrefs.Schema{
+		prefsCount:         {Type: XPrefs.TypeInteger, Range: positive},
+		prefsStep:          {Type: XPrefs.TypeInteger, Range: positive},
+		prefsQueue:         {Type: XPrefs.TypeInteger, Range: positive},
+		prefsStarve:        {Type: XPrefs.TypeInteger, Range: positive},
+		prefsDrainTimeout:  {Type: XPrefs.TypeInteger, Range: nonNegative},
+		prefsDrainTimer:    {Type: XPrefs.TypeString, Enum: []any{drainTimerFire, drainTimerCancel}},
+		prefsBudget:        {Type: XPrefs.TypeInteger, Range: nonNegative},
+		prefsLegacyMetrics: {Type: XPrefs.TypeBoolean},
+		prefsLockThread:    {Type: XPrefs.TypeBoolean},
+		prefsWorkers:       {Type: XPrefs.TypeInteger, Range: positive},
+		prefsWorkerQueue:   {Type: XPrefs.TypeInteger, Range: positive},
+		prefsMetricsPrefix: {Type: XPrefs.TypeString, Check: func(value any) error {
+			if !metricsPrefixPattern.MatchString(value.(string)) {
+				return fmt.Errorf("invalid metrics prefix %q", value)
+			}
+			return nil
+		}},
+		prefsAffinity: {Type: XPrefs.TypeArray, Items: &XPrefs.Schema{Type: XPrefs.TypeString, Check: func(value any) error {
+			_, err := parseCPUSet(value.(string))
+			return err
+		}}},
+	})
+	setup(XPrefs.Asset())
+}
+
+// New 创建并启动线程池。
+// prefs 为线程池的配置，配置项与默认线程池相同，如 Loom/Count、Loom/Step、Loom/Queue、Loom/MetricsPrefix 等，为 nil 时使用默认配置。
+// 度量默认注册至 prometheus.DefaultRegisterer，多个线程池需使用不同的 Loom/MetricsPrefix 以避免度量名称冲突。
+// 线程池同样响应退出信号，不再使用时需调用 Close 关闭，配置无效时抛出异常。
+func New(prefs XPrefs.IBase) *Pool {
+	if prefs == nil {
+		prefs = XPrefs.New()
+	}
+	p := &Pool{metricsReg: prometheus.DefaultRegisterer}
+	p.setup(prefs)
+	return p
+}
+
+// Default 返回默认线程池，包级别的函数均在该线程池中执行。
+func Default() *Pool { return defaultPool }
+
+// setup 初始化默认线程池。
+func setup(prefs XPrefs.IBase) { defaultPool.setup(prefs) }
+
+// looms 返回默认线程池的线程列表。
+func looms() []*loom { return defaultPool.looms() }
+
+// getLoom 获取默认线程池中指定 ID 的线程，ID 越界时返回 nil。
+func getLoom(lid int) *loom { return defaultPool.getLoom(lid) }
+
+// setup 初始化线程池，已存在的线程、看门狗及工作池将被关闭并重新创建。
+func (p *Pool) setup(prefs XPrefs.IBase) {
+	if prefs == nil {
+		XLog.Panic("XLoom.Init: prefs is nil.")
+		return
+	}
+
+	p.initMu.Lock()
+	defer p.initMu.Unlock()
+
+	count := prefs.GetInt(prefsCount, prefsCountDefault)
+	step := prefs.GetInt(prefsStep, prefsStepDefault)
+	queue := prefs.GetInt(prefsQueue, prefsQueueDefault)
+	starve := prefs.GetInt(prefsStarve, prefsStarveDefault)
+	drainTimeout := prefs.GetInt(prefsDrainTimeout, prefsDrainTimeoutDefault)
+	drainTimer := prefs.GetString(prefsDrainTimer, prefsDrainTimerDefault)
+	budget := prefs.GetInt(prefsBudget, prefsBudgetDefault)
+	legacy := prefs.GetBool(prefsLegacyMetrics, prefsLegacyMetricsDefault)
+	prefix := prefs.GetString(prefsMetricsPrefix, prefsMetricsPrefixDefault)
+	workers := prefs.GetInt(prefsWorkers, prefsWorkersDefault)
+	workerQueue := prefs.GetInt(prefsWorkerQueue, prefsWorkerQueueDefault)
+	lockThread := prefs.GetBool(prefsLockThread, prefsLockThreadDefault)
+	affinity := [][]int{}
+	for _, spec := range prefs.GetStrings(prefsAffinity) {
+		cpus, err := parseCPUSet(spec)
+		if err != nil {
+			XLog.Panic("XLoom.Init: invalid affinity: %v.", err)
+			return
+		}
+		affinity = append(affinity, cpus)
+	}
+
+	if count <= 0 || step <= 0 || queue <= 0 || starve <= 0 || drainTimeout < 0 || budget < 0 ||
+		(drainTimer != drainTimerFire && drainTimer != drainTimerCancel) || workers <= 0 || workerQueue <= 0 ||
+		!metricsPrefixPattern.MatchString(prefix) {
+		XLog.Panic("XLoom.Init: invalid parameters, count: %v, step: %v, queue: %v, starve: %v, drainTimeout: %v, drainTimer: %v, budget: %v, workers: %v, workerQueue: %v, metricsPrefix: %q.",
+			count, step, queue, starve, drainTimeout, drainTimer, budget, workers, workerQueue, prefix)
+		return
+	}
+
+	// 关闭看门狗、工作池及所有线程。
+	p.stopWatchdog()
+	p.stopWorkers()
+	for _, l := range p.looms() {
+		l.close(false)
+	}
+
+	p.closed = false
+	p.step = step
+	p.queue = queue
+	p.starve = starve
+	p.drainTimeout = drainTimeout
+	p.drainTimer = drainTimer
+	p.budget = budget
+	p.lockThread = lockThread
+	p.affinity = affinity
+
+	p.setupMetrics(legacy, prefix)
+
+	list := make([]*loom, count)
+	for i := range count {
+		list[i] = p.newLoom(i)
+	}
+	p.list.Store(&list)
+
+	p.startWorkers(workers, workerQueue)
+
+	if budget > 0 {
+		p.startWatchdog(budget)
+	}
+
+	XLog.Notice("XLoom.Init: allocated %v loom(s) and %v worker(s), lock thread: %v, affinity: %v.", count, workers, lockThread || len(affinity) > 0, affinity)
+}
+
+// Close 关闭线程池，各线程在 Loom/DrainTimeout 时间内排空剩余的任务后退出，并触发 OnDrain 回调。
+// 关闭后线程池不再接收新的任务，工作池不再接收新的阻塞任务，度量将从注册器中注销。
+// 不能在线程池的线程中调用该函数，重复调用无副作用。
+func (p *Pool) Close() {
+	if lid := p.ID(); lid >= 0 {
+		XLog.Critical("XLoom.Close: can not close pool from loom %v of itself.", lid)
+		return
+	}
+
+	p.initMu.Lock()
+	defer p.initMu.Unlock()
+	if p.closed {
+		return
+	}
+	p.closed = true
+
+	wg := sync.WaitGroup{}
+	for _, l := range p.looms() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.close(true)
+		}()
+	}
+	wg.Wait()
+
+	p.stopWatchdog()
+	p.stopWorkers()
+	p.closeMetrics()
+
+	XLog.Notice("XLoom.Close: closed %v loom(s).", p.Count())
+}
+
+// looms 返回线程池当前的线程列表。
+func (p *Pool) looms() []*loom {
+	if list := p.list.Load(); list != nil {
+		return *list
+	}
+	return nil
+}
+
+// getLoom 获取线程池中指定 ID 的线程，ID 越界时返回 nil。
+func (p *Pool) getLoom(lid int) *loom {
+	list := p.looms()
+	if lid < 0 || lid >= len(list) {
+		return nil
+	}
+	return list[lid]
+}
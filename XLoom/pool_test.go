@@ -0,0 +1,154 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/eframework-org/GO.UTIL/XPrefs"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPool(t *testing.T) {
+	prefs := func(prefix string) XPrefs.IBase {
+		return XPrefs.New().Set(prefsCount, 2).Set(prefsStep, 10).Set(prefsQueue, 1000).
+			Set(prefsWorkers, 2).Set(prefsMetricsPrefix, prefix)
+	}
+
+	t.Run("Isolation", func(t *testing.T) {
+		p := New(prefs("xloom_isolation"))
+		defer p.Close()
+		assert.Equal(t, 2, p.Count(), "线程池的线程数量应当为 2")
+		assert.NotSame(t, Default(), p, "新建的线程池不应当为默认线程池")
+
+		ids := make(chan [2]int, 1)
+		p.RunIn(func() { ids <- [2]int{p.ID(), ID()} }, 1)
+		select {
+		case id := <-ids:
+			assert.Equal(t, 1, id[0], "线程池的线程 ID 应当为 1")
+			assert.Equal(t, -1, id[1], "线程池的线程不应当属于默认线程池")
+		case <-time.After(time.Second):
+			t.Fatal("任务执行超时")
+		}
+
+		fired := make(chan int, 1)
+		assert.Greater(t, p.SetTimeout(func() { fired <- p.ID() }, 10, 1), 0)
+		select {
+		case lid := <-fired:
+			assert.Equal(t, 1, lid, "超时调用应当在线程池的线程中触发")
+		case <-time.After(time.Second):
+			t.Fatal("超时调用触发超时")
+		}
+		assert.Empty(t, Timers(0), "默认线程池中不应当存在线程池的定时器")
+
+		keyed := make(chan int, 1)
+		p.RunInKey("player", func() { keyed <- p.ID() })
+		assert.Equal(t, p.LoomOf("player"), <-keyed, "键值任务应当在线程池中键值所绑定的线程中执行")
+	})
+
+	t.Run("Offload", func(t *testing.T) {
+		p := New(prefs("xloom_offload"))
+		defer p.Close()
+
+		done := make(chan int, 1)
+		p.RunIn(func() {
+			Offload(func() (int, error) { return 42, nil }, func(int, error) { done <- p.ID() })
+		}, 1)
+		select {
+		case lid := <-done:
+			assert.Equal(t, 1, lid, "结果回调应当投递回线程池中发起调用的线程")
+		case <-time.After(time.Second):
+			t.Fatal("结果回调执行超时")
+		}
+		assert.False(t, OffloadTo[int](nil, func() (int, error) { return 0, nil }, nil), "空的线程池应当投递失败")
+
+		g, _ := NewGroup(context.Background(), WithPool(p))
+		g.GoIn(1, func(ctx context.Context) error {
+			assert.Equal(t, 1, LoomFrom(ctx))
+			assert.Equal(t, 1, p.ID(), "任务组的任务应当投递至指定的线程池")
+			return nil
+		})
+		assert.NoError(t, g.Wait())
+	})
+
+	t.Run("Metrics", func(t *testing.T) {
+		p := New(prefs("xloom_metrics"))
+		defer p.Close()
+
+		_, found := gatherFrom(t, prometheus.DefaultGatherer, "xloom_metrics_fps", "loom", "1")
+		assert.True(t, found, "线程池的度量应当使用度量前缀")
+
+		reg := prometheus.NewRegistry()
+		assert.NoError(t, p.SetRegisterer(reg))
+		_, found = gatherFrom(t, reg, "xloom_metrics_qps", "loom", "0")
+		assert.True(t, found, "线程池的度量应当注册至新的注册器")
+		_, found = gatherFrom(t, reg, "xloom_qps")
+		assert.False(t, found, "默认线程池的度量不应当注册至线程池的注册器")
+		_, found = gatherFrom(t, prometheus.DefaultGatherer, "xloom_metrics_fps")
+		assert.False(t, found, "线程池的度量应当从原注册器中注销")
+
+		p.Close()
+		_, found = gatherFrom(t, reg, "xloom_metrics_fps")
+		assert.False(t, found, "关闭后线程池的度量应当被注销")
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		p := New(prefs("xloom_close"))
+		reports := make(chan DrainReport, 2)
+		p.OnDrain(func(report DrainReport) { reports <- report })
+
+		var executed atomic.Int32
+		p.Pause(0)
+		for range 5 {
+			p.RunIn(func() { executed.Add(1) }, 0)
+		}
+		p.Close()
+		assert.Equal(t, int32(5), executed.Load(), "关闭时应当执行剩余的任务")
+		assert.Len(t, reports, 2, "关闭时每个线程均应当触发排空回调")
+
+		rejected := true
+		p.RunIn(func() { rejected = false }, 0)
+		time.Sleep(time.Millisecond * 50)
+		assert.True(t, rejected, "关闭后投递的任务应当被拒绝")
+		assert.NotPanics(t, p.Close, "重复关闭不应当发生异常")
+		p.Resize(3)
+		assert.Equal(t, 2, p.Count(), "关闭后不应当调整线程池的大小")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Panics(t, func() { New(prefs("1xloom")) }, "无效的度量前缀应当抛出异常")
+
+		p := New(prefs("xloom_invalid"))
+		defer p.Close()
+		done := make(chan struct{})
+		p.RunIn(func() {
+			p.Close() // 不能在线程池的线程中关闭线程池
+			close(done)
+		}, 0)
+		<-done
+		ran := make(chan struct{})
+		p.RunIn(func() { close(ran) }, 1)
+		select {
+		case <-ran:
+		case <-time.After(time.Second):
+			t.Fatal("在线程中关闭线程池不应当生效")
+		}
+	})
+
+	t.Run("Schema", func(t *testing.T) {
+		assert.NoError(t, XPrefs.Validate(prefs("xloom_schema").Set(prefsAffinity, []string{"0-1", "2"})))
+
+		err := XPrefs.Validate(prefs("1xloom").Set(prefsCount, 0).Set(prefsDrainTimer, "skip").
+			Set(prefsAffinity, []string{"0-1", "x"}).Set("Loom/Cuont", 2))
+		assert.Error(t, err)
+		for _, key := range []string{prefsCount, prefsDrainTimer, prefsMetricsPrefix, prefsAffinity + "[1]", "Loom/Cuont: unknown key"} {
+			assert.ErrorContains(t, err, key, "无效的配置项应当被报告")
+		}
+	})
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import "time"
+
+// Priority 定义了任务的优先级。
+type Priority int
+
+const (
+	PriorityHigh   Priority = iota // 高优先级，用于踢出玩家、关闭服务等紧急的控制消息
+	PriorityNormal                 // 普通优先级，RunIn 投递的任务默认使用该优先级
+	PriorityLow                    // 低优先级，用于统计、日志等可延迟处理的任务
+	priorityCount                  // 优先级数量
+)
+
+// String 返回优先级的名称。
+func (p Priority) String() string {
+	switch p {
+	case PriorityHigh:
+		return "high"
+	case PriorityNormal:
+		return "normal"
+	case PriorityLow:
+		return "low"
+	default:
+		return "unknown"
+	}
+}
+
+// taskItem 定义了任务队列中的任务。
+type taskItem struct {
+	fn func()    // 任务函数
+	at time.Time // 投递时间，用于统计排队时延
+}
+
+// TaskOption 定义了任务投递的可选参数。
+type TaskOption func(*taskOption)
+
+// taskOption 定义了任务投递的参数集合。
+type taskOption struct {
+	loomID   int      // 目标线程 ID
+	priority Priority // 任务优先级
+}
+
+// WithLoom 指定任务投递的目标线程。
+func WithLoom(loomID int) TaskOption {
+	return func(opt *taskOption) { opt.loomID = loomID }
+}
+
+// WithPriority 指定任务投递的优先级。
+func WithPriority(priority Priority) TaskOption {
+	return func(opt *taskOption) { opt.priority = priority }
+}
+
+// wakeup 唤醒线程处理任务，若已存在唤醒信号则忽略。
+func (l *loom) wakeup() {
+	select {
+	case l.wake <- struct{}{}:
+	default:
+	}
+}
+
+// pendingTask 判断线程是否存在待处理的任务。
+func (l *loom) pendingTask() bool {
+	for p := range priorityCount {
+		if len(l.task[p]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// pickTask 按照优先级从线程的任务队列中取出一个任务。
+// 高优先级的任务总是优先处理，但非空的低优先级通道被连续跳过的次数达到饥饿阈值时，
+// 将优先处理该通道的任务，以避免低优先级任务被无限期延迟。
+func (l *loom) pickTask() (taskItem, Priority) {
+	lanes := l.task
+	skips := &l.skip
+
+	for p := priorityCount - 1; p > PriorityHigh; p-- {
+		if skips[p] >= l.pool.starve {
+			select {
+			case task := <-lanes[p]:
+				skips[p] = 0
+				return task, p
+			default:
+				skips[p] = 0
+			}
+		}
+	}
+
+	for p := range priorityCount {
+		select {
+		case task := <-lanes[p]:
+			skips[p] = 0
+			for q := p + 1; q < priorityCount; q++ {
+				if len(lanes[q]) > 0 {
+					skips[q]++
+				}
+			}
+			return task, p
+		default:
+		}
+	}
+	return taskItem{}, PriorityNormal
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eframework-org/GO.UTIL/XPrefs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriority(t *testing.T) {
+	// collect 暂停线程并投递任务，恢复后返回任务的执行顺序
+	collect := func(t *testing.T, post func(record func(string) func())) []string {
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		orders := []string{}
+		record := func(name string) func() {
+			wg.Add(1)
+			return func() {
+				mu.Lock()
+				orders = append(orders, name)
+				mu.Unlock()
+				wg.Done()
+			}
+		}
+
+		Pause(0)
+		time.Sleep(time.Millisecond * 50) // 等待线程进入暂停状态
+		post(record)
+		Resume(0)
+
+		done := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("任务执行超时")
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		return orders
+	}
+
+	t.Run("Order", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 1).Set(prefsStep, 10).Set(prefsQueue, 1000))
+
+		orders := collect(t, func(record func(string) func()) {
+			for range 3 {
+				RunInWith(record("low"), WithPriority(PriorityLow))
+				RunIn(record("normal"), 0)
+				RunInWith(record("high"), WithLoom(0), WithPriority(PriorityHigh))
+			}
+		})
+		assert.Equal(t, []string{"high", "high", "high", "normal", "normal", "normal", "low", "low", "low"}, orders, "任务应当按照优先级执行")
+
+		assert.Equal(t, 3, int(gather(t, "xloom_tasks_total", "loom", "0", "lane", "high")), "高优先级处理总数应当为 3")
+		assert.Equal(t, 3, int(gather(t, "xloom_tasks_total", "loom", "0", "lane", "normal")), "普通优先级处理总数应当为 3")
+		assert.Equal(t, 3, int(gather(t, "xloom_tasks_total", "loom", "0", "lane", "low")), "低优先级处理总数应当为 3")
+	})
+
+	t.Run("Starve", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 1).Set(prefsStep, 10).Set(prefsQueue, 1000).Set(prefsStarve, 2))
+
+		orders := collect(t, func(record func(string) func()) {
+			RunInWith(record("low"), WithPriority(PriorityLow))
+			for range 10 {
+				RunInWith(record("high"), WithPriority(PriorityHigh))
+			}
+		})
+		assert.Equal(t, "low", orders[2], "低优先级任务被连续跳过 2 次后应当优先执行")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 1).Set(prefsStep, 10).Set(prefsQueue, 1000))
+
+		executed := false
+		RunInWith(func() { executed = true }, WithPriority(priorityCount))
+		RunInWith(func() { executed = true }, WithLoom(-1))
+		RunInWith(func() { executed = true }, WithLoom(999))
+		RunInWith(nil)
+		time.Sleep(time.Millisecond * 50)
+		assert.False(t, executed, "非法参数的任务不应当被执行")
+	})
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"github.com/eframework-org/GO.UTIL/XLog"
+)
+
+// migrateTarget 获取退役线程的迁移目标线程。
+// 退役线程的任务、定时器及帧回调将迁移至 ID 为 lid % Count() 的线程。
+func (p *Pool) migrateTarget(lid int) *loom {
+	list := p.looms()
+	if len(list) == 0 {
+		return nil
+	}
+	return list[lid%len(list)]
+}
+
+// Resize 调整默认线程池的大小，参见 Pool.Resize。
+func Resize(count int) { defaultPool.Resize(count) }
+
+// Resize 调整线程池的大小。
+// count 为新的线程数量，必须大于 0。
+// 扩容时新增的线程立即启动；缩容时退役线程在执行完当前任务后退出，
+// 其未处理的任务、定时器及帧回调将迁移至 ID 为 loomID % count 的线程。
+// 调整完成后按照注册顺序调用 OnResize 回调，用于键值绑定等业务的重新平衡。
+// 不能在即将退役的线程中调用该函数。
+func (p *Pool) Resize(count int) {
+	if count <= 0 {
+		XLog.Critical("XLoom.Resize: count of %v can not be zero or negative.", count)
+		return
+	}
+	if lid := p.ID(); lid >= count {
+		XLog.Critical("XLoom.Resize: can not retire loom %v from itself.", lid)
+		return
+	}
+
+	p.initMu.Lock()
+	if p.closed {
+		p.initMu.Unlock()
+		XLog.Critical("XLoom.Resize: pool is closed, resize was rejected.")
+		return
+	}
+	old := p.looms()
+	oldCount := len(old)
+	if count == oldCount {
+		p.initMu.Unlock()
+		return
+	}
+
+	if count > oldCount {
+		list := make([]*loom, count)
+		copy(list, old)
+		for i := oldCount; i < count; i++ {
+			list[i] = p.newLoom(i)
+		}
+		p.list.Store(&list)
+	} else {
+		list := make([]*loom, count)
+		copy(list, old)
+		p.list.Store(&list)
+
+		for _, l := range old[count:] {
+			l.retire(list[l.id%count])
+		}
+	}
+	p.initMu.Unlock()
+
+	XLog.Notice("XLoom.Resize: resized loom(s) from %v to %v.", oldCount, count)
+
+	p.resizeHooks.each(func(callback func(int, int)) { callback(oldCount, count) })
+}
+
+// retire 退役线程，并将其未处理的任务、定时器及帧回调迁移至目标线程。
+func (l *loom) retire(target *loom) {
+	// 停止主循环，此后投递至该线程的任务暂存于队列中。
+	l.close(false)
+
+	// 持有锁迁移队列中的任务后再标记为退役状态，此后投递至该线程的任务将转发至目标线程；
+	// 迁移期间的投递等待迁移完成，保证任务按照投递的顺序执行。
+	l.mu.Lock()
+	tasks := 0
+	for p := range priorityCount {
+		for len(l.task[p]) > 0 {
+			if runIn((<-l.task[p]).fn, target, p) {
+				tasks++
+			}
+		}
+	}
+	l.retired = true
+	l.mu.Unlock()
+
+	timers := l.timers.migrate(&target.timers)
+	hooks := migrateUpdate(l, target)
+
+	XLog.Notice("XLoom.Resize: retired loom %v and migrated %v task(s), %v timer(s), %v hook(s) to loom %v.", l.id, tasks, timers, hooks, target.id)
+}
+
+// OnResize 注册默认线程池扩缩容的回调，参见 Pool.OnResize。
+func OnResize(callback func(oldCount, newCount int)) (unsub func()) {
+	return defaultPool.OnResize(callback)
+}
+
+// OnResize 注册线程池扩缩容的回调。
+// callback 为扩缩容回调函数，oldCount 为调整前的线程数量，newCount 为调整后的线程数量。
+// 键值绑定的业务可在回调中使用 LoomOf 重新计算键值所属的线程，并迁移相应的业务数据。
+// 返回注销函数，可在任意 goroutine 中调用，重复调用无副作用。
+func (p *Pool) OnResize(callback func(oldCount, newCount int)) (unsub func()) {
+	if callback == nil {
+		XLog.Critical("XLoom.OnResize: callback can not be nil.")
+		return func() {}
+	}
+
+	return p.resizeHooks.add(callback)
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/eframework-org/GO.UTIL/XPrefs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResize(t *testing.T) {
+	setup(XPrefs.New().Set(prefsCount, 2).Set(prefsStep, 10).Set(prefsQueue, 1000))
+
+	var hookMu sync.Mutex
+	resizes := [][2]int{}
+	unsub := OnResize(func(oldCount, newCount int) {
+		hookMu.Lock()
+		resizes = append(resizes, [2]int{oldCount, newCount})
+		hookMu.Unlock()
+	})
+	defer unsub()
+
+	t.Run("Grow", func(t *testing.T) {
+		Resize(4)
+		assert.Equal(t, 4, Count(), "扩容后应当有 4 个线程")
+
+		done := make(chan int, 1)
+		RunIn(func() { done <- ID() }, 3)
+		select {
+		case lid := <-done:
+			assert.Equal(t, 3, lid, "新增的线程应当可以执行任务")
+		case <-time.After(time.Second):
+			t.Fatal("新增的线程执行任务超时")
+		}
+	})
+
+	t.Run("Shrink", func(t *testing.T) {
+		retired := getLoom(3)
+		gid := retired.gid.Load()
+		assert.Equal(t, 3, ID(gid), "退役前的 goroutine 应当映射至线程 3")
+
+		var mu sync.Mutex
+		taskLooms := []int{}
+		hookLooms := []int{}
+		timerLoom := -1
+
+		// 暂停线程 3 并堆积任务、定时器及帧回调
+		Pause(3)
+		time.Sleep(time.Millisecond * 50)
+		for range 10 {
+			RunIn(func() {
+				mu.Lock()
+				taskLooms = append(taskLooms, ID())
+				mu.Unlock()
+			}, 3)
+		}
+		SetTimeout(func() {
+			mu.Lock()
+			timerLoom = ID()
+			mu.Unlock()
+		}, 100, 3)
+		cleared := true
+		tm := SetTimeout(func() { cleared = false }, 100, 3)
+		ClearTimeout(tm, 3)
+		var ticks atomic.Int32
+		iv := SetInterval(func() { ticks.Add(1) }, 20, 3)
+		unsubHook := OnUpdate(3, func(delta int) {
+			mu.Lock()
+			hookLooms = append(hookLooms, ID())
+			mu.Unlock()
+		})
+
+		Resize(2)
+		assert.Equal(t, 2, Count(), "缩容后应当有 2 个线程")
+		assert.Equal(t, -1, ID(gid), "退役线程的 goroutine 不应当映射至任何线程")
+
+		ClearInterval(iv, 3)
+		time.Sleep(time.Millisecond * 300)
+		unsubHook()
+		fired := ticks.Load()
+		time.Sleep(time.Millisecond * 100)
+		assert.Equal(t, fired, ticks.Load(), "使用退役线程的 ID 应当能够取消迁移后的定时器")
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Len(t, taskLooms, 10, "退役线程未处理的任务应当迁移执行")
+		for _, lid := range taskLooms {
+			assert.Equal(t, 1, lid, "退役线程的任务应当迁移至线程 3 % 2")
+		}
+		assert.Equal(t, 1, timerLoom, "退役线程的定时器应当迁移至线程 3 % 2")
+		assert.True(t, cleared, "已清除的定时器不应当被迁移")
+		assert.NotEmpty(t, hookLooms, "退役线程的帧回调应当迁移执行")
+		for _, lid := range hookLooms {
+			assert.Equal(t, 1, lid, "退役线程的帧回调应当迁移至线程 3 % 2")
+		}
+
+		// 退役线程的引用仍可投递任务，任务将转发至目标线程
+		done := make(chan int, 1)
+		runIn(func() { done <- ID() }, retired, PriorityNormal)
+		select {
+		case lid := <-done:
+			assert.Equal(t, 1, lid, "投递至退役线程的任务应当转发至目标线程")
+		case <-time.After(time.Second):
+			t.Fatal("转发的任务执行超时")
+		}
+	})
+
+	t.Run("Hook", func(t *testing.T) {
+		hookMu.Lock()
+		defer hookMu.Unlock()
+		assert.Equal(t, [][2]int{{2, 4}, {4, 2}}, resizes, "扩缩容后应当调用回调")
+	})
+
+	t.Run("Order", func(t *testing.T) {
+		Resize(4)
+		retiring := getLoom(3)
+
+		var mu sync.Mutex
+		order := []int{}
+		record := func(i int) func() {
+			return func() {
+				mu.Lock()
+				order = append(order, i)
+				mu.Unlock()
+			}
+		}
+
+		// 阻塞线程 3 并堆积任务，缩容期间继续向退役线程投递任务
+		gate := make(chan struct{})
+		started := make(chan struct{})
+		RunIn(func() {
+			close(started)
+			<-gate
+		}, 3)
+		<-started
+		for i := range 5 {
+			RunIn(record(i), 3)
+		}
+		resized := make(chan struct{})
+		go func() {
+			Resize(2)
+			close(resized)
+		}()
+		assert.Eventually(t, func() bool { return Count() == 2 }, time.Second, time.Millisecond)
+		time.Sleep(time.Millisecond * 20)
+		for i := 5; i < 10; i++ {
+			runIn(record(i), retiring, PriorityNormal)
+		}
+		close(gate)
+		<-resized
+
+		assert.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(order) == 10
+		}, time.Second, time.Millisecond*10)
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, order, "缩容期间投递的任务应当在已堆积的任务之后执行")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		Resize(0)
+		Resize(-1)
+		assert.Equal(t, 2, Count(), "非法的线程数量不应当调整线程池")
+		assert.NotNil(t, OnResize(nil), "传入空的回调函数应当返回空的注销函数")
+	})
+}
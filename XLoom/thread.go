@@ -0,0 +1,95 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/eframework-org/GO.UTIL/XLog"
+)
+
+const cpuSetSize = 1024 // CPU 集合的最大容量，与 Linux 的 cpu_set_t 一致
+
+// errAffinityUnsupported 表示当前平台不支持设置 CPU 亲和性。
+var errAffinityUnsupported = errors.New("cpu affinity is unsupported on " + runtime.GOOS)
+
+// parseCPUSet 解析 CPU 集合。
+// spec 为 CPU 集合，使用逗号分隔的编号或范围，如 "0-3,6"。
+// 返回升序且不重复的 CPU 编号及解析过程中发生的错误。
+func parseCPUSet(spec string) ([]int, error) {
+	var mask [cpuSetSize]bool
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		lo, hi, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(strings.TrimSpace(lo))
+		if err != nil {
+			return nil, fmt.Errorf("invalid cpu %q in %q", lo, spec)
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(strings.TrimSpace(hi)); err != nil {
+				return nil, fmt.Errorf("invalid cpu %q in %q", hi, spec)
+			}
+		}
+		if start < 0 || end >= cpuSetSize || start > end {
+			return nil, fmt.Errorf("cpu range %q is out of range [0, %v) in %q", part, cpuSetSize, spec)
+		}
+		for cpu := start; cpu <= end; cpu++ {
+			mask[cpu] = true
+		}
+	}
+	cpus := []int{}
+	for cpu, ok := range mask {
+		if ok {
+			cpus = append(cpus, cpu)
+		}
+	}
+	return cpus, nil
+}
+
+// bindThread 将线程的 goroutine 锁定至当前的系统线程并按需设置 CPU 亲和性，在线程的主循环开始时调用。
+// 锁定后不解除锁定，主循环退出时 goroutine 所在的系统线程随之销毁，避免 CPU 亲和性泄漏至其他 goroutine。
+func (l *loom) bindThread() {
+	if !l.pool.lockThread && len(l.cpus) == 0 {
+		l.tid.Store(-1)
+		return
+	}
+	runtime.LockOSThread()
+	l.tid.Store(int64(threadID()))
+	if len(l.cpus) > 0 {
+		if err := setAffinity(l.cpus); err != nil {
+			XLog.Warn("XLoom.Loop(%v): set affinity to cpu %v failed: %v, fallback to scheduling of os.", l.id, l.cpus, err)
+		}
+	}
+}
+
+// ThreadID 获取默认线程池中指定线程所在的系统线程 ID，参见 Pool.ThreadID。
+func ThreadID(loomID ...int) int { return defaultPool.ThreadID(loomID...) }
+
+// ThreadID 获取指定线程所在的系统线程 ID，如 Linux 的 tid，可用于 perf、top -H 等工具的排查。
+// loomID 为可选的目标线程 ID，如果未指定，返回当前线程的系统线程 ID。
+// 仅在启用 Loom/LockThread 或 Loom/Affinity 时线程固定于系统线程，否则返回 -1；不支持获取系统线程 ID 的平台亦返回 -1。
+func (p *Pool) ThreadID(loomID ...int) int {
+	lid := -1
+	if len(loomID) == 1 {
+		lid = loomID[0]
+	} else {
+		lid = p.ID()
+	}
+	if lid < 0 {
+		XLog.Critical("XLoom.ThreadID: loom id of %v can not be zero or negative.", lid)
+		return -1
+	}
+	l := p.getLoom(lid)
+	if l == nil {
+		XLog.Critical("XLoom.ThreadID: loom id of %v can not equals or greater than: %v.", lid, p.Count())
+		return -1
+	}
+	return int(l.tid.Load())
+}
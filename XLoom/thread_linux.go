@@ -0,0 +1,47 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+//go:build linux
+
+package XLoom
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// cpuMask 定义了与 Linux 的 cpu_set_t 布局一致的 CPU 掩码。
+type cpuMask [cpuSetSize / 64]uint64
+
+// threadID 获取当前系统线程的 ID。
+func threadID() int { return syscall.Gettid() }
+
+// setAffinity 通过 sched_setaffinity 设置当前系统线程的 CPU 亲和性。
+func setAffinity(cpus []int) error {
+	var mask cpuMask
+	for _, cpu := range cpus {
+		mask[cpu/64] |= 1 << (cpu % 64)
+	}
+	_, _, errno := syscall.RawSyscall(syscall.SYS_SCHED_SETAFFINITY, 0, unsafe.Sizeof(mask), uintptr(unsafe.Pointer(&mask)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// getAffinity 通过 sched_getaffinity 获取当前系统线程的 CPU 亲和性。
+func getAffinity() ([]int, error) {
+	var mask cpuMask
+	_, _, errno := syscall.RawSyscall(syscall.SYS_SCHED_GETAFFINITY, 0, unsafe.Sizeof(mask), uintptr(unsafe.Pointer(&mask)))
+	if errno != 0 {
+		return nil, errno
+	}
+	cpus := []int{}
+	for cpu := range cpuSetSize {
+		if mask[cpu/64]&(1<<(cpu%64)) != 0 {
+			cpus = append(cpus, cpu)
+		}
+	}
+	return cpus, nil
+}
@@ -0,0 +1,16 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux
+
+package XLoom
+
+// threadID 获取当前系统线程的 ID，当前平台不支持时返回 -1。
+func threadID() int { return -1 }
+
+// setAffinity 设置当前系统线程的 CPU 亲和性，当前平台不支持时返回错误，线程仍由系统调度。
+func setAffinity(cpus []int) error { return errAffinityUnsupported }
+
+// getAffinity 获取当前系统线程的 CPU 亲和性，当前平台不支持时返回错误。
+func getAffinity() ([]int, error) { return nil, errAffinityUnsupported }
@@ -0,0 +1,101 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/eframework-org/GO.UTIL/XPrefs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThread(t *testing.T) {
+	defer setup(XPrefs.Asset())
+
+	// runOn 在指定线程中执行函数并等待其返回
+	runOn := func(t *testing.T, lid int, fn func()) {
+		done := make(chan struct{})
+		RunIn(func() {
+			defer close(done)
+			fn()
+		}, lid)
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("任务执行超时")
+		}
+	}
+
+	t.Run("Parse", func(t *testing.T) {
+		cpus, err := parseCPUSet("0-3,6")
+		assert.NoError(t, err)
+		assert.Equal(t, []int{0, 1, 2, 3, 6}, cpus)
+		cpus, err = parseCPUSet(" 2 , 1-2")
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, cpus, "重复的 CPU 应当被合并")
+
+		for _, spec := range []string{"", "a", "3-1", "-1", "1024", "0-", "1,,2"} {
+			_, err := parseCPUSet(spec)
+			assert.Error(t, err, "CPU 集合 %q 应当解析失败", spec)
+		}
+	})
+
+	t.Run("Unlocked", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 1).Set(prefsStep, 10).Set(prefsQueue, 1000))
+		assert.Equal(t, -1, ThreadID(0), "未锁定系统线程时应当返回 -1")
+		assert.Equal(t, -1, ThreadID(999), "无效的线程 ID 应当返回 -1")
+	})
+
+	t.Run("LockThread", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 2).Set(prefsStep, 10).Set(prefsQueue, 1000).Set(prefsLockThread, true))
+		if runtime.GOOS != "linux" {
+			assert.Equal(t, -1, ThreadID(0), "不支持的平台应当返回 -1")
+			return
+		}
+
+		tid := ThreadID(0)
+		assert.Greater(t, tid, 0, "锁定系统线程后应当返回系统线程 ID")
+		assert.NotEqual(t, tid, ThreadID(1), "各线程应当锁定至独立的系统线程")
+		for range 3 {
+			runOn(t, 0, func() {
+				assert.Equal(t, tid, threadID(), "线程的任务应当总是在锁定的系统线程中执行")
+				assert.Equal(t, tid, ThreadID(), "在线程中调用时应当返回当前线程的系统线程 ID")
+			})
+			time.Sleep(time.Millisecond * 20)
+		}
+	})
+
+	t.Run("Affinity", func(t *testing.T) {
+		if runtime.GOOS != "linux" {
+			t.Skip("仅 Linux 支持设置 CPU 亲和性")
+		}
+
+		setup(XPrefs.New().Set(prefsCount, 2).Set(prefsStep, 10).Set(prefsQueue, 1000).Set(prefsAffinity, []string{"0"}))
+		assert.Greater(t, ThreadID(1), 0, "设置 CPU 亲和性时应当锁定系统线程")
+		for lid := range 2 {
+			runOn(t, lid, func() {
+				cpus, err := getAffinity()
+				assert.NoError(t, err)
+				assert.Equal(t, []int{0}, cpus, "线程应当绑定至指定的 CPU 集合")
+			})
+		}
+
+		// 不存在的 CPU 设置失败时回退至系统调度
+		setup(XPrefs.New().Set(prefsCount, 1).Set(prefsStep, 10).Set(prefsQueue, 1000).Set(prefsAffinity, []string{"1023"}))
+		runOn(t, 0, func() {
+			cpus, err := getAffinity()
+			assert.NoError(t, err)
+			assert.NotEqual(t, []int{1023}, cpus, "设置失败时应当保留原有的 CPU 亲和性")
+		})
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Panics(t, func() {
+			setup(XPrefs.New().Set(prefsAffinity, []string{"0-x"}))
+		}, "无效的 CPU 集合应当抛出异常")
+	})
+}
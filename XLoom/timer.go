@@ -5,173 +5,373 @@
 package XLoom
 
 import (
+	"slices"
 	"sync"
 	"sync/atomic"
 
 	"github.com/eframework-org/GO.UTIL/XLog"
 )
 
+const (
+	timerPending = iota // 等待触发
+	timerFired          // 已触发，仅用于超时调用
+	timerStopped        // 已停止
+)
+
 var (
 	timerPool = sync.Pool{New: func() any {
 		obj := new(timer)
 		return obj
 	}}
-	timerIID    int64      // 定时器自增标识
-	allTimers   [][]*timer // 所有定时器
-	newTimers   [][]*timer // 新的定时器
-	newTimersLk []sync.Mutex
-	delTimers   [][]int // 待删除的定时器
-	delTimersLk []sync.Mutex
+	timerIID int64 // 定时器自增标识
 )
 
+// timerQueue 定义了线程的定时器队列。
+type timerQueue struct {
+	allTimers   []*timer   // 所有定时器
+	allTimersLk sync.Mutex // 保护所有定时器的增删，用于在其他 goroutine 中遍历定时器
+	newTimers   []*timer   // 新的定时器
+	newTimersLk sync.Mutex
+	delTimers   []int // 待删除的定时器
+	delTimersLk sync.Mutex
+	count       atomic.Int64 // 定时器数量，包含新的定时器及待删除的定时器
+}
+
 // timer 定义了一个定时器的基本结构。
 type timer struct {
-	id       int    // 定时器唯一标识
-	callback func() // 定时器触发时执行的回调函数
-	period   int    // 定时器周期（毫秒），用于重复执行的间歇时间
-	tick     int    // 当前剩余时间（毫秒），倒计时到 0 时触发回调
-	repeat   bool   // 是否重复执行，true 表示间歇调用，false 表示超时调用
-	panic    bool   // 是否发生异常，用于异常恢复控制
+	id       int          // 定时器唯一标识
+	callback func()       // 定时器触发时执行的回调函数
+	pc       uintptr      // 调用方回调函数的地址，用于定时器信息及慢执行报告
+	period   atomic.Int64 // 定时器周期（毫秒），用于重复执行的间歇时间
+	tick     atomic.Int64 // 当前剩余时间（毫秒），倒计时到 0 时触发回调
+	repeat   bool         // 是否重复执行，true 表示间歇调用，false 表示超时调用
+	panic    bool         // 是否发生异常，用于异常恢复控制
+	delay    func() int   // 下一次触发的剩余时间（毫秒），为 nil 时使用 period，用于计划任务
+	state    atomic.Int32 // 定时器状态：等待触发、已触发或已停止
+	paused   atomic.Bool  // 是否已暂停，暂停时不再倒计时
+	handled  bool         // 是否被 Timer 句柄引用，被引用的定时器不放回对象池
 }
 
 // reset 重置定时器到初始状态。
 func (tm *timer) reset() *timer {
 	tm.id = 0
 	tm.callback = nil
-	tm.period = 0
-	tm.tick = 0
+	tm.pc = 0
+	tm.period.Store(0)
+	tm.tick.Store(0)
 	tm.repeat = false
 	tm.panic = false
+	tm.delay = nil
+	tm.state.Store(timerPending)
+	tm.paused.Store(false)
+	tm.handled = false
 	return tm
 }
 
-// setupTimer 初始化定时器系统。
-func setupTimer(num int) {
-	allTimers = make([][]*timer, num)
-	newTimers = make([][]*timer, num)
-	newTimersLk = make([]sync.Mutex, num)
-	delTimers = make([][]int, num)
-	delTimersLk = make([]sync.Mutex, num)
+// release 将定时器放回对象池，被 Timer 句柄引用的定时器保留其状态。
+func (tm *timer) release() {
+	if !tm.handled {
+		timerPool.Put(tm.reset())
+	}
+}
+
+// rewind 重新开始间歇调用的倒计时。
+func (tm *timer) rewind() {
+	if tm.delay != nil {
+		tm.tick.Store(int64(tm.delay()))
+	} else {
+		tm.tick.Store(tm.period.Load())
+	}
 }
 
-// updateTimer 更新指定线成的定时器状态。
-func updateTimer(pid int, delta int) {
-	if len(newTimers[pid]) > 0 {
-		newTimersLk[pid].Lock()
-		allTimers[pid] = append(allTimers[pid], newTimers[pid]...)
-		newTimers[pid] = newTimers[pid][:0]
-		newTimersLk[pid].Unlock()
+// update 更新定时器队列的状态。
+// invoke 为定时器回调的执行函数，用于统计回调的执行耗时，pc 为调用方回调函数的地址。
+func (tq *timerQueue) update(delta int, invoke func(pc uintptr, callback func())) {
+	tq.allTimersLk.Lock()
+	tq.newTimersLk.Lock()
+	if len(tq.newTimers) > 0 {
+		tq.allTimers = append(tq.allTimers, tq.newTimers...)
+		tq.newTimers = tq.newTimers[:0]
 	}
-	if len(delTimers[pid]) > 0 {
-		delTimersLk[pid].Lock()
-		for _, id := range delTimers[pid] {
-			for idx, timer := range allTimers[pid] {
+	tq.newTimersLk.Unlock()
+	tq.delTimersLk.Lock()
+	if len(tq.delTimers) > 0 {
+		for _, id := range tq.delTimers {
+			for idx, timer := range tq.allTimers {
 				if id == timer.id {
-					allTimers[pid] = append(allTimers[pid][:idx], allTimers[pid][idx+1:]...)
-					timerPool.Put(timer.reset())
+					tq.allTimers = append(tq.allTimers[:idx], tq.allTimers[idx+1:]...)
+					tq.count.Add(-1)
+					timer.state.CompareAndSwap(timerPending, timerStopped)
+					timer.release()
 					break
 				}
 			}
 		}
-		delTimers[pid] = delTimers[pid][:0]
-		delTimersLk[pid].Unlock()
-	}
-	if allTimers[pid] != nil {
-		for _, timer := range allTimers[pid] {
-			timer.tick -= delta
-			if timer.panic {
-				if timer.repeat { // interval 发生 panic 不取消定时器
-					timer.panic = false
-					timer.tick = timer.period
-				} else { // timeout 发生 panic 则直接移除
-					delTimersLk[pid].Lock()
-					delTimers[pid] = append(delTimers[pid], timer.id)
-					delTimersLk[pid].Unlock()
-					continue
-				}
+		tq.delTimers = tq.delTimers[:0]
+	}
+	tq.delTimersLk.Unlock()
+	tq.allTimersLk.Unlock()
+
+	for _, timer := range tq.allTimers {
+		if timer.state.Load() == timerStopped {
+			tq.remove(timer.id)
+			continue
+		}
+		if timer.paused.Load() {
+			continue
+		}
+		tick := timer.tick.Add(-int64(delta))
+		if timer.panic {
+			if timer.repeat { // interval 发生 panic 不取消定时器
+				timer.panic = false
+				timer.rewind()
+				tick = timer.tick.Load()
+			} else { // timeout 发生 panic 则直接移除
+				tq.remove(timer.id)
+				continue
 			}
-			if timer.tick <= 0 { // 因存在固定刷新间歇，可能会导致间歇调用的周期越来越长
-				if timer.callback != nil {
-					timer.panic = true
-					timer.callback()
-					timer.panic = false
-				}
-				if !timer.repeat {
-					delTimersLk[pid].Lock()
-					delTimers[pid] = append(delTimers[pid], timer.id)
-					delTimersLk[pid].Unlock()
-				} else {
-					timer.tick = timer.period
-				}
+		}
+		if tick <= 0 { // 因存在固定刷新间歇，可能会导致间歇调用的周期越来越长
+			if !timer.repeat && !timer.state.CompareAndSwap(timerPending, timerFired) {
+				tq.remove(timer.id) // 超时调用已被停止
+				continue
+			}
+			if timer.callback != nil {
+				timer.panic = true
+				invoke(timer.pc, timer.callback)
+				timer.panic = false
+			}
+			if !timer.repeat {
+				tq.remove(timer.id)
+			} else {
+				timer.rewind()
 			}
 		}
 	}
 }
 
-// SetTimeout 设置一个超时调用。
-// callback 为要执行的回调函数。
-// timeout 为超时时间（毫秒）。
-// loomID 为可选的目标线程 ID，如果未指定，在当前线程中执行。
-// 返回定时器 ID，如果参数无效则返回 -1。
-func SetTimeout(callback func(), timeout int, loomID ...int) int {
+// add 添加定时器，在下一次更新时生效。
+func (tq *timerQueue) add(timer *timer) {
+	tq.newTimersLk.Lock()
+	tq.newTimers = append(tq.newTimers, timer)
+	tq.count.Add(1)
+	tq.newTimersLk.Unlock()
+}
+
+// remove 移除定时器，在下一次更新时生效。
+func (tq *timerQueue) remove(id int) {
+	tq.delTimersLk.Lock()
+	tq.delTimers = append(tq.delTimers, id)
+	tq.delTimersLk.Unlock()
+}
+
+// has 检查定时器队列中是否包含指定 ID 的定时器。
+func (tq *timerQueue) has(id int) bool {
+	tq.allTimersLk.Lock()
+	defer tq.allTimersLk.Unlock()
+	tq.newTimersLk.Lock()
+	defer tq.newTimersLk.Unlock()
+	for _, timers := range [][]*timer{tq.allTimers, tq.newTimers} {
+		for _, timer := range timers {
+			if timer.id == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// take 取出定时器队列中的所有定时器及待删除的定时器 ID。
+func (tq *timerQueue) take() (timers []*timer, dels []int) {
+	tq.allTimersLk.Lock()
+	tq.newTimersLk.Lock()
+	timers = append(tq.allTimers, tq.newTimers...)
+	tq.allTimers = nil
+	tq.newTimers = nil
+	tq.count.Add(-int64(len(timers)))
+	tq.newTimersLk.Unlock()
+	tq.allTimersLk.Unlock()
+
+	tq.delTimersLk.Lock()
+	dels = tq.delTimers
+	tq.delTimers = nil
+	tq.delTimersLk.Unlock()
+	return
+}
+
+// flush 清空定时器队列，调用时定时器队列所属的线程不再更新。
+// fire 为是否立即触发剩余的超时调用，间歇调用总是被取消。
+// 返回触发及取消的定时器数量。
+func (tq *timerQueue) flush(fire bool) (fired int, cancelled int) {
+	timers, dels := tq.take()
+	for _, timer := range timers {
+		if slices.Contains(dels, timer.id) || (timer.panic && !timer.repeat) || timer.state.Load() != timerPending {
+			timer.release()
+			continue
+		}
+		if fire && !timer.repeat && timer.callback != nil && timer.state.CompareAndSwap(timerPending, timerFired) {
+			func() {
+				defer XLog.Caught(false)
+				timer.callback()
+			}()
+			fired++
+		} else {
+			timer.state.CompareAndSwap(timerPending, timerStopped)
+			cancelled++
+		}
+		timer.release()
+	}
+	return
+}
+
+// migrate 将定时器队列中未移除的定时器迁移至目标队列，并保留其剩余时间。
+// 调用时定时器队列所属的线程必须已经退出。
+func (tq *timerQueue) migrate(target *timerQueue) int {
+	timers, dels := tq.take()
+	count := 0
+	for _, timer := range timers {
+		if slices.Contains(dels, timer.id) || (timer.panic && !timer.repeat) || timer.state.Load() != timerPending {
+			timer.release()
+			continue
+		}
+		target.add(timer)
+		count++
+	}
+	return count
+}
+
+// snapshot 返回定时器队列中等待触发的定时器信息。
+func (tq *timerQueue) snapshot() []TimerInfo {
+	tq.allTimersLk.Lock()
+	defer tq.allTimersLk.Unlock()
+	tq.newTimersLk.Lock()
+	timers := append(slices.Clone(tq.allTimers), tq.newTimers...)
+	tq.newTimersLk.Unlock()
+	tq.delTimersLk.Lock()
+	dels := slices.Clone(tq.delTimers)
+	tq.delTimersLk.Unlock()
+
+	infos := make([]TimerInfo, 0, len(timers))
+	for _, timer := range timers {
+		if slices.Contains(dels, timer.id) || timer.state.Load() != timerPending {
+			continue
+		}
+		infos = append(infos, TimerInfo{
+			ID:        timer.id,
+			Func:      funcName(timer.pc),
+			Remaining: max(int(timer.tick.Load()), 0),
+			Period:    int(timer.period.Load()),
+			Repeat:    timer.repeat,
+			Paused:    timer.paused.Load(),
+		})
+	}
+	return infos
+}
+
+// newTimer 在线程池中创建定时器，需由调用方添加至返回线程的定时器队列。
+// name 为调用方的名称，用于输出日志。
+// 返回创建的定时器及目标线程，如果参数无效则返回 nil。
+func (p *Pool) newTimer(name string, callback func(), tick int, repeat bool, loomID []int) (*timer, *loom) {
 	if callback == nil {
-		XLog.Critical("XLoom.SetTimeout: callback can not be nil.")
-		return -1
+		XLog.Critical("XLoom.%v: callback can not be nil.", name)
+		return nil, nil
 	}
-	if timeout < 0 {
-		XLog.Critical("XLoom.SetTimeout: timeout of %v can not be zero or negative.", timeout)
-		return -1
+	if tick < 0 {
+		arg := "timeout"
+		if repeat {
+			arg = "interval"
+		}
+		XLog.Critical("XLoom.%v: %v of %v can not be zero or negative.", name, arg, tick)
+		return nil, nil
 	}
 	lid := -1
 	if len(loomID) == 1 {
 		lid = loomID[0]
 	} else {
-		lid = ID()
+		lid = p.ID()
 	}
 	if lid < 0 {
-		XLog.Critical("XLoom.SetTimeout: loom id of %v can not be zero or negative.", lid)
-		return -1
+		XLog.Critical("XLoom.%v: loom id of %v can not be zero or negative.", name, lid)
+		return nil, nil
 	}
-	if lid >= loomCount {
-		XLog.Critical("XLoom.SetTimeout: loom id of %v can not equals or greater than: %v", lid, Count())
-		return -1
+	l := p.getLoom(lid)
+	if l == nil {
+		XLog.Critical("XLoom.%v: loom id of %v can not equals or greater than: %v", name, lid, p.Count())
+		return nil, nil
 	}
 
 	timer := timerPool.Get().(*timer)
 	timer.id = int(atomic.AddInt64(&timerIID, 1))
 	timer.callback = callback
-	timer.tick = timeout
-	timer.period = timer.tick
-	timer.repeat = false
+	timer.pc = funcPC(callback)
+	timer.tick.Store(int64(tick))
+	timer.period.Store(int64(tick))
+	timer.repeat = repeat
+	return timer, l
+}
+
+// SetTimeout 在默认线程池中设置一个超时调用，参见 Pool.SetTimeout。
+func SetTimeout(callback func(), timeout int, loomID ...int) int {
+	return defaultPool.SetTimeout(callback, timeout, loomID...)
+}
 
-	newTimersLk[lid].Lock()
-	newTimers[lid] = append(newTimers[lid], timer)
-	newTimersLk[lid].Unlock()
-	return timer.id
+// SetTimeout 设置一个超时调用。
+// callback 为要执行的回调函数。
+// timeout 为超时时间（毫秒）。
+// loomID 为可选的目标线程 ID，如果未指定，在当前线程中执行。
+// 返回定时器 ID，如果参数无效则返回 -1。
+func (p *Pool) SetTimeout(callback func(), timeout int, loomID ...int) int {
+	timer, l := p.newTimer("SetTimeout", callback, timeout, false, loomID)
+	if timer == nil {
+		return -1
+	}
+	id := timer.id // 添加后的定时器可能被立即触发并回收
+	l.timers.add(timer)
+	return id
 }
 
+// ClearTimeout 取消默认线程池中的一个超时调用，参见 Pool.ClearTimeout。
+func ClearTimeout(id int, loomID ...int) { defaultPool.ClearTimeout(id, loomID...) }
+
 // ClearTimeout 取消一个超时调用。
 // id 为要取消的定时器 ID。
-// loomID 为可选的目标线程 ID，如果未指定，在当前线程中执行。
-func ClearTimeout(id int, loomID ...int) {
+// loomID 为可选的目标线程 ID，如果未指定，在当前线程中执行；线程已在缩容时退役的，取消迁移后的定时器。
+func (p *Pool) ClearTimeout(id int, loomID ...int) {
 	lid := -1
 	if len(loomID) == 1 {
 		lid = loomID[0]
 	} else {
-		lid = ID()
+		lid = p.ID()
 	}
 	if lid < 0 {
 		XLog.Critical("XLoom.ClearTimeout: loom id of %v can not be zero or negative.", lid)
 		return
 	}
-	if lid >= loomCount {
-		XLog.Critical("XLoom.ClearTimeout: loom id of %v can not equals or greater than: %v", lid, Count())
-		return
+	l := p.getLoom(lid)
+	if l == nil {
+		// 线程已在缩容时退役，其定时器已迁移至其他线程，按照定时器 ID 查找。
+		if l = p.timerLoom(id); l == nil {
+			XLog.Warn("XLoom.ClearTimeout: timer %v was not found, loom id of %v equals or greater than: %v", id, lid, p.Count())
+			return
+		}
 	}
 
-	delTimersLk[lid].Lock()
-	delTimers[lid] = append(delTimers[lid], id)
-	delTimersLk[lid].Unlock()
+	l.timers.remove(id)
+}
+
+// timerLoom 查找持有指定定时器的线程，未找到时返回 nil。
+func (p *Pool) timerLoom(id int) *loom {
+	for _, l := range p.looms() {
+		if l.timers.has(id) {
+			return l
+		}
+	}
+	return nil
+}
+
+// SetInterval 在默认线程池中设置一个间歇调用，参见 Pool.SetInterval。
+func SetInterval(callback func(), interval int, loomID ...int) int {
+	return defaultPool.SetInterval(callback, interval, loomID...)
 }
 
 // SetInterval 设置一个间歇调用。
@@ -179,44 +379,144 @@ func ClearTimeout(id int, loomID ...int) {
 // interval 为调用间歇（毫秒）。
 // loomID 为可选的目标线程 ID，如果未指定，在当前线程中执行。
 // 返回定时器 ID，如果参数无效则返回 -1。
-func SetInterval(callback func(), interval int, loomID ...int) int {
-	if callback == nil {
-		XLog.Critical("XLoom.SetInterval: callback can not be nil.")
+func (p *Pool) SetInterval(callback func(), interval int, loomID ...int) int {
+	timer, l := p.newTimer("SetInterval", callback, interval, true, loomID)
+	if timer == nil {
 		return -1
 	}
-	if interval < 0 {
-		XLog.Critical("XLoom.SetInterval: interval of %v can not be zero or negative.", interval)
-		return -1
+	id := timer.id // 添加后的定时器可能被立即触发并回收
+	l.timers.add(timer)
+	return id
+}
+
+// ClearInterval 取消默认线程池中的一个间歇调用，参见 Pool.ClearInterval。
+func ClearInterval(id int, loomID ...int) { defaultPool.ClearInterval(id, loomID...) }
+
+// ClearInterval 取消一个间歇调用。
+// id 为要取消的定时器 ID。
+// loomID 为可选的目标线程 ID，如果未指定，在当前线程中执行。
+func (p *Pool) ClearInterval(id int, loomID ...int) { p.ClearTimeout(id, loomID...) }
+
+// Timer 定义了定时器的句柄，用于查询及控制定时器，可在任意 goroutine 中调用。
+type Timer struct {
+	tm *timer // 定时器，被句柄引用的定时器不放回对象池
+}
+
+// TimerInfo 定义了定时器的快照信息，用于调试及排查定时器泄漏。
+type TimerInfo struct {
+	ID        int    // 定时器 ID
+	Func      string // 回调函数名称
+	Remaining int    // 距离下一次触发的剩余时间（毫秒）
+	Period    int    // 定时器周期（毫秒）
+	Repeat    bool   // 是否为间歇调用
+	Paused    bool   // 是否已暂停
+}
+
+// NewTimeout 在默认线程池中设置一个超时调用并返回其句柄，参见 Pool.NewTimeout。
+func NewTimeout(callback func(), timeout int, loomID ...int) *Timer {
+	return defaultPool.NewTimeout(callback, timeout, loomID...)
+}
+
+// NewTimeout 设置一个超时调用并返回其句柄。
+// callback 为要执行的回调函数。
+// timeout 为超时时间（毫秒）。
+// loomID 为可选的目标线程 ID，如果未指定，在当前线程中执行。
+// 返回定时器句柄，如果参数无效则返回 nil。
+func (p *Pool) NewTimeout(callback func(), timeout int, loomID ...int) *Timer {
+	timer, l := p.newTimer("NewTimeout", callback, timeout, false, loomID)
+	if timer == nil {
+		return nil
+	}
+	timer.handled = true
+	l.timers.add(timer)
+	return &Timer{tm: timer}
+}
+
+// NewInterval 在默认线程池中设置一个间歇调用并返回其句柄，参见 Pool.NewInterval。
+func NewInterval(callback func(), interval int, loomID ...int) *Timer {
+	return defaultPool.NewInterval(callback, interval, loomID...)
+}
+
+// NewInterval 设置一个间歇调用并返回其句柄。
+// callback 为要执行的回调函数。
+// interval 为调用间歇（毫秒）。
+// loomID 为可选的目标线程 ID，如果未指定，在当前线程中执行。
+// 返回定时器句柄，如果参数无效则返回 nil。
+func (p *Pool) NewInterval(callback func(), interval int, loomID ...int) *Timer {
+	timer, l := p.newTimer("NewInterval", callback, interval, true, loomID)
+	if timer == nil {
+		return nil
 	}
+	timer.handled = true
+	l.timers.add(timer)
+	return &Timer{tm: timer}
+}
+
+// ID 返回定时器 ID，可以使用 ClearTimeout 或 ClearInterval 取消。
+func (t *Timer) ID() int { return t.tm.id }
+
+// Pending 返回定时器是否等待触发，超时调用触发后或定时器停止后返回 false。
+func (t *Timer) Pending() bool { return t.tm.state.Load() == timerPending }
+
+// Paused 返回定时器是否已暂停。
+func (t *Timer) Paused() bool { return t.tm.paused.Load() }
+
+// Remaining 返回距离下一次触发的剩余时间（毫秒），定时器不再等待触发时返回 0。
+// 定时器按照线程的帧更新倒计时，实际的触发时间可能晚于剩余时间。
+func (t *Timer) Remaining() int {
+	if !t.Pending() {
+		return 0
+	}
+	return max(int(t.tm.tick.Load()), 0)
+}
+
+// Reset 重新设置定时器的剩余时间，间歇调用的周期同时被修改。
+// ms 为新的剩余时间（毫秒）。
+// 返回是否设置成功，定时器不再等待触发或参数无效时返回 false。
+func (t *Timer) Reset(ms int) bool {
+	if ms < 0 {
+		XLog.Critical("XLoom.Timer.Reset: ms of %v can not be negative.", ms)
+		return false
+	}
+	if !t.Pending() {
+		return false
+	}
+	t.tm.period.Store(int64(ms))
+	t.tm.tick.Store(int64(ms))
+	return true
+}
+
+// Pause 暂停定时器的倒计时，暂停期间剩余时间保持不变。
+func (t *Timer) Pause() { t.tm.paused.Store(true) }
+
+// Resume 恢复定时器的倒计时。
+func (t *Timer) Resume() { t.tm.paused.Store(false) }
+
+// Stop 停止定时器，定时器将在下一次更新时移除。
+// 返回是否由本次调用停止，定时器已触发或已停止时返回 false。
+func (t *Timer) Stop() bool { return t.tm.state.CompareAndSwap(timerPending, timerStopped) }
+
+// Timers 返回默认线程池中指定线程等待触发的定时器信息，参见 Pool.Timers。
+func Timers(loomID ...int) []TimerInfo { return defaultPool.Timers(loomID...) }
+
+// Timers 返回指定线程中等待触发的定时器信息，可用于排查定时器泄漏。
+// loomID 为可选的目标线程 ID，如果未指定，返回当前线程的定时器。
+// 返回定时器信息的快照，如果线程 ID 无效则返回 nil。
+func (p *Pool) Timers(loomID ...int) []TimerInfo {
 	lid := -1
 	if len(loomID) == 1 {
 		lid = loomID[0]
 	} else {
-		lid = ID()
+		lid = p.ID()
 	}
 	if lid < 0 {
-		XLog.Critical("XLoom.SetInterval: loom id of %v can not be zero or negative.", lid)
-		return -1
+		XLog.Critical("XLoom.Timers: loom id of %v can not be zero or negative.", lid)
+		return nil
 	}
-	if lid >= loomCount {
-		XLog.Critical("XLoom.SetInterval: loom id of %v can not equals or greater than: %v", lid, Count())
-		return -1
+	l := p.getLoom(lid)
+	if l == nil {
+		XLog.Critical("XLoom.Timers: loom id of %v can not equals or greater than: %v", lid, p.Count())
+		return nil
 	}
-
-	timer := timerPool.Get().(*timer)
-	timer.id = int(atomic.AddInt64(&timerIID, 1))
-	timer.callback = callback
-	timer.tick = interval
-	timer.period = timer.tick
-	timer.repeat = true
-
-	newTimersLk[lid].Lock()
-	newTimers[lid] = append(newTimers[lid], timer)
-	newTimersLk[lid].Unlock()
-	return timer.id
+	return l.timers.snapshot()
 }
-
-// ClearInterval 取消一个间歇调用。
-// id 为要取消的定时器 ID。
-// loomID 为可选的目标线程 ID，如果未指定，在当前线程中执行。
-func ClearInterval(id int, loomID ...int) { ClearTimeout(id, loomID...) }
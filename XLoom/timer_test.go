@@ -5,6 +5,7 @@
 package XLoom
 
 import (
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -94,4 +95,99 @@ func TestTimer(t *testing.T) {
 		assert.Equal(t, -1, SetInterval(func() {}, 100, -1), "传入非法的 loomID 应当返回 -1")
 		assert.Equal(t, -1, SetInterval(func() {}, 100, 999), "传入越界的 loomID 应当返回 -1")
 	})
+
+	t.Run("Handle", func(t *testing.T) {
+		fired := make(chan struct{}, 1)
+		tm := NewTimeout(func() { fired <- struct{}{} }, 300, 0)
+		if !assert.NotNil(t, tm, "定时器句柄应当创建成功") {
+			return
+		}
+		assert.Greater(t, tm.ID(), 0, "定时器 ID 应该为正数")
+		assert.True(t, tm.Pending(), "新建的定时器应当等待触发")
+		time.Sleep(time.Millisecond * 100)
+		assert.InDelta(t, 200, tm.Remaining(), 60, "剩余时间应当随帧更新减少")
+
+		// 暂停期间剩余时间保持不变
+		tm.Pause()
+		assert.True(t, tm.Paused())
+		time.Sleep(time.Millisecond * 30)
+		remaining := tm.Remaining()
+		time.Sleep(time.Millisecond * 200)
+		assert.Equal(t, remaining, tm.Remaining(), "暂停期间剩余时间应当保持不变")
+		tm.Resume()
+		assert.False(t, tm.Paused())
+
+		assert.True(t, tm.Reset(50), "等待触发的定时器应当重置成功")
+		select {
+		case <-fired:
+		case <-time.After(time.Second):
+			t.Fatal("定时器回调超时")
+		}
+		time.Sleep(time.Millisecond * 30)
+		assert.False(t, tm.Pending(), "触发后的超时调用不应当等待触发")
+		assert.Equal(t, 0, tm.Remaining(), "触发后的剩余时间应当为 0")
+		assert.False(t, tm.Stop(), "已触发的定时器应当停止失败")
+		assert.False(t, tm.Reset(100), "已触发的定时器应当重置失败")
+
+		// 停止间歇调用
+		var count atomic.Int32
+		it := NewInterval(func() { count.Add(1) }, 10, 1)
+		time.Sleep(time.Millisecond * 100)
+		assert.True(t, it.Stop(), "等待触发的定时器应当停止成功")
+		assert.False(t, it.Stop(), "重复停止应当返回 false")
+		time.Sleep(time.Millisecond * 30)
+		stopped := count.Load()
+		time.Sleep(time.Millisecond * 100)
+		assert.Equal(t, stopped, count.Load(), "停止后的间歇调用不应当被回调")
+
+		// 通过 ID 取消的定时器句柄不再等待触发
+		ct := NewTimeout(func() {}, 1000, 0)
+		ClearTimeout(ct.ID(), 0)
+		time.Sleep(time.Millisecond * 50)
+		assert.False(t, ct.Pending(), "取消后的定时器不应当等待触发")
+
+		assert.Nil(t, NewTimeout(nil, 100, 0), "传入空的回调函数应当返回 nil")
+		assert.Nil(t, NewInterval(func() {}, -1, 0), "传入小于零的间歇应当返回 nil")
+		assert.Nil(t, NewTimeout(func() {}, 100, 999), "传入越界的 loomID 应当返回 nil")
+	})
+
+	t.Run("Timers", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 2).Set(prefsStep, 10).Set(prefsQueue, 1000))
+
+		id := SetTimeout(func() {}, 10000, 1)
+		it := NewInterval(func() {}, 5000, 1)
+		it.Pause()
+
+		infos := Timers(1)
+		if assert.Len(t, infos, 2, "应当返回所有等待触发的定时器") {
+			byID := map[int]TimerInfo{}
+			for _, info := range infos {
+				byID[info.ID] = info
+			}
+			assert.False(t, byID[id].Repeat)
+			assert.Equal(t, 10000, byID[id].Period)
+			assert.Contains(t, byID[id].Func, "TestTimer", "应当包含回调函数的名称")
+			assert.True(t, byID[it.ID()].Repeat)
+			assert.True(t, byID[it.ID()].Paused)
+		}
+
+		ClearTimeout(id, 1)
+		it.Stop()
+		assert.Empty(t, Timers(1), "取消后的定时器不应当被返回")
+		assert.Nil(t, Timers(999), "传入越界的 loomID 应当返回 nil")
+
+		// 并发访问定时器
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for range 100 {
+				Timers(1)
+				it.Remaining()
+			}
+		}()
+		for range 100 {
+			SetTimeout(func() {}, 1, 1)
+		}
+		<-done
+	})
 }
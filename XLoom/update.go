@@ -0,0 +1,166 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/eframework-org/GO.UTIL/XLog"
+)
+
+var (
+	updateIID int64      // 帧回调自增标识
+	updateMu  sync.Mutex // 帧回调互斥锁，用于保护回调列表的写入及回调的迁移
+)
+
+// updateHook 定义了帧回调的基本结构。
+type updateHook struct {
+	id       int64           // 帧回调唯一标识
+	callback func(delta int) // 帧回调函数，delta 为距离上一帧的时间（毫秒）
+	late     bool            // 是否为帧后更新回调
+	owner    *loom           // 回调所属的线程，线程退役时迁移至目标线程
+}
+
+// hooks 返回线程指定阶段的帧回调列表。
+func (l *loom) hooks(late bool) *atomic.Pointer[[]*updateHook] {
+	if late {
+		return &l.lateHooks
+	}
+	return &l.updateHooks
+}
+
+// runUpdate 执行指定线程的帧回调。
+// 单个回调发生异常不会影响其他回调的执行。
+func runUpdate(hooks *atomic.Pointer[[]*updateHook], delta int) {
+	list := hooks.Load()
+	if list == nil {
+		return
+	}
+	for _, hook := range *list {
+		func() {
+			defer XLog.Caught(false)
+			hook.callback(delta)
+		}()
+	}
+}
+
+// attachHook 将帧回调添加至所属线程的回调列表，调用时需持有 updateMu。
+func attachHook(hook *updateHook) {
+	target := hook.owner.hooks(hook.late)
+	list := []*updateHook{}
+	if old := target.Load(); old != nil {
+		list = append(list, *old...)
+	}
+	list = append(list, hook)
+	target.Store(&list)
+}
+
+// detachHook 将帧回调从所属线程的回调列表中移除，调用时需持有 updateMu。
+func detachHook(hook *updateHook) {
+	target := hook.owner.hooks(hook.late)
+	old := target.Load()
+	if old == nil {
+		return
+	}
+	list := make([]*updateHook, 0, len(*old))
+	for _, h := range *old {
+		if h.id != hook.id {
+			list = append(list, h)
+		}
+	}
+	target.Store(&list)
+}
+
+// migrateUpdate 将线程的帧回调迁移至目标线程，返回迁移的回调数量。
+func migrateUpdate(l *loom, target *loom) int {
+	updateMu.Lock()
+	defer updateMu.Unlock()
+	count := 0
+	for _, late := range []bool{false, true} {
+		list := l.hooks(late).Swap(nil)
+		if list == nil {
+			continue
+		}
+		for _, hook := range *list {
+			hook.owner = target
+			attachHook(hook)
+			count++
+		}
+	}
+	return count
+}
+
+// newUpdate 注册线程池中指定线程的帧回调，如果参数无效则返回 nil。
+func (p *Pool) newUpdate(name string, late bool, loomID int, callback func(delta int)) *updateHook {
+	if callback == nil {
+		XLog.Critical("XLoom.%v: callback can not be nil.", name)
+		return nil
+	}
+	if loomID < 0 {
+		XLog.Critical("XLoom.%v: loom id of %v can not be zero or negative.", name, loomID)
+		return nil
+	}
+	l := p.getLoom(loomID)
+	if l == nil {
+		XLog.Critical("XLoom.%v: loom id of %v can not equals or greater than: %v.", name, loomID, p.Count())
+		return nil
+	}
+
+	hook := &updateHook{id: atomic.AddInt64(&updateIID, 1), callback: callback, late: late, owner: l}
+	updateMu.Lock()
+	attachHook(hook)
+	updateMu.Unlock()
+	return hook
+}
+
+// detach 注销帧回调，重复调用无副作用。
+func (hook *updateHook) detach() {
+	updateMu.Lock()
+	defer updateMu.Unlock()
+	detachHook(hook)
+}
+
+// loom 返回帧回调当前所属的线程，线程退役后返回迁移的目标线程。
+func (hook *updateHook) loom() *loom {
+	updateMu.Lock()
+	defer updateMu.Unlock()
+	return hook.owner
+}
+
+// addUpdate 注册线程池中指定线程的帧回调并返回注销函数。
+func (p *Pool) addUpdate(name string, late bool, loomID int, callback func(delta int)) func() {
+	hook := p.newUpdate(name, late, loomID, callback)
+	if hook == nil {
+		return func() {}
+	}
+	return hook.detach
+}
+
+// OnUpdate 注册默认线程池中指定线程的帧更新回调，参见 Pool.OnUpdate。
+func OnUpdate(loomID int, callback func(delta int)) (unsub func()) {
+	return defaultPool.OnUpdate(loomID, callback)
+}
+
+// OnUpdate 注册指定线程的帧更新回调。
+// loomID 为目标线程 ID。
+// callback 为帧更新回调函数，delta 为距离上一帧的时间（毫秒），在定时器更新之前执行。
+// 返回注销函数，可在任意 goroutine 中调用，重复调用无副作用。
+func (p *Pool) OnUpdate(loomID int, callback func(delta int)) (unsub func()) {
+	return p.addUpdate("OnUpdate", false, loomID, callback)
+}
+
+// OnLateUpdate 注册默认线程池中指定线程的帧后更新回调，参见 Pool.OnLateUpdate。
+func OnLateUpdate(loomID int, callback func(delta int)) (unsub func()) {
+	return defaultPool.OnLateUpdate(loomID, callback)
+}
+
+// OnLateUpdate 注册指定线程的帧后更新回调。
+// loomID 为目标线程 ID。
+// callback 为帧后更新回调函数，delta 为距离上一帧的时间（毫秒），在定时器更新之后执行。
+// 返回注销函数，可在任意 goroutine 中调用，重复调用无副作用。
+func (p *Pool) OnLateUpdate(loomID int, callback func(delta int)) (unsub func()) {
+	return p.addUpdate("OnLateUpdate", true, loomID, callback)
+}
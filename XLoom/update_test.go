@@ -0,0 +1,94 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eframework-org/GO.UTIL/XCollect"
+	"github.com/eframework-org/GO.UTIL/XPrefs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdate(t *testing.T) {
+	setup(XPrefs.New().Set(prefsCount, 2).Set(prefsStep, 10).Set(prefsQueue, 1000))
+
+	t.Run("Order", func(t *testing.T) {
+		var mu sync.Mutex
+		phases := []string{}
+		deltas := []int{}
+		looms := []int{}
+
+		unsubUpdate := OnUpdate(1, func(delta int) {
+			mu.Lock()
+			defer mu.Unlock()
+			phases = append(phases, "update")
+			deltas = append(deltas, delta)
+			looms = append(looms, ID())
+		})
+		unsubLate := OnLateUpdate(1, func(delta int) {
+			mu.Lock()
+			defer mu.Unlock()
+			phases = append(phases, "late")
+			looms = append(looms, ID())
+		})
+		tm := SetTimeout(func() {
+			mu.Lock()
+			defer mu.Unlock()
+			phases = append(phases, "timer")
+		}, 0, 1)
+		assert.Greater(t, tm, 0)
+
+		time.Sleep(time.Millisecond * 200)
+		unsubUpdate()
+		unsubLate()
+		unsubLate() // 重复注销无副作用
+
+		mu.Lock()
+		count := len(phases)
+		assert.Greater(t, len(deltas), 5, "帧更新回调应当被多次调用")
+		idx := XCollect.Index(phases, "timer")
+		assert.Greater(t, idx, 0, "定时器回调应当被调用")
+		assert.Equal(t, []string{"update", "timer", "late"}, phases[idx-1:idx+2], "帧回调应当按照更新、定时器、后更新的顺序执行")
+		for _, lid := range looms {
+			assert.Equal(t, 1, lid, "帧回调应当在所属线程中执行")
+		}
+		for _, delta := range deltas {
+			assert.GreaterOrEqual(t, delta, 0, "帧间隔应当大于等于 0")
+		}
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond * 100)
+		mu.Lock()
+		assert.Equal(t, count, len(phases), "注销后帧回调不应当被调用")
+		mu.Unlock()
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		var mu sync.Mutex
+		count := 0
+		unsubPanic := OnUpdate(0, func(delta int) { panic("test update panic") })
+		unsubCount := OnUpdate(0, func(delta int) {
+			mu.Lock()
+			count++
+			mu.Unlock()
+		})
+		time.Sleep(time.Millisecond * 100)
+		unsubPanic()
+		unsubCount()
+
+		mu.Lock()
+		assert.Greater(t, count, 1, "发生异常后其他帧回调应当继续执行")
+		mu.Unlock()
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		assert.NotNil(t, OnUpdate(0, nil), "传入空的回调函数应当返回空的注销函数")
+		assert.NotNil(t, OnUpdate(-1, func(int) {}), "传入非法的 loomID 应当返回空的注销函数")
+		assert.NotNil(t, OnLateUpdate(999, func(int) {}), "传入越界的 loomID 应当返回空的注销函数")
+	})
+}
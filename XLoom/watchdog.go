@@ -0,0 +1,186 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/eframework-org/GO.UTIL/XLog"
+)
+
+const (
+	watchTask  = iota // 任务
+	watchTimer        // 定时器回调
+	watchFrame        // 帧更新，包含帧回调及定时器回调
+	watchCount        // 监视类型数量
+)
+
+// watchKinds 为监视类型的名称。
+var watchKinds = [watchCount]string{"task", "timer", "frame"}
+
+// SlowReport 定义了慢执行报告。
+type SlowReport struct {
+	Loom    int    // 线程 ID
+	Kind    string // 执行类型：task、timer 或 frame
+	Func    string // 执行的函数名称，帧更新为空
+	Elapsed int    // 执行耗时（毫秒），若由看门狗检测则为检测时的耗时
+	Budget  int    // 执行预算（毫秒）
+	Stack   string // 线程 goroutine 的堆栈，若执行结束后才检测到则为空
+}
+
+// watch 定义了线程当前执行的监视状态。
+type watch struct {
+	seq      atomic.Uint64  // 执行序号，每次执行自增
+	start    atomic.Int64   // 执行开始时间（毫秒），0 表示空闲
+	pc       atomic.Uintptr // 执行的函数地址
+	reported atomic.Uint64  // 已报告的执行序号，避免重复报告
+}
+
+// funcPC 获取函数的地址。
+func funcPC(fn func()) uintptr { return reflect.ValueOf(fn).Pointer() }
+
+// funcName 获取函数地址对应的函数名称。
+func funcName(pc uintptr) string {
+	if pc == 0 {
+		return ""
+	}
+	if fn := runtime.FuncForPC(pc); fn != nil {
+		return fn.Name()
+	}
+	return fmt.Sprintf("0x%x", pc)
+}
+
+// goroutineStack 获取指定 goroutine 的堆栈。
+func goroutineStack(gid int64) string {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, len(buf)*2)
+	}
+	prefix := fmt.Sprintf("goroutine %d [", gid)
+	for _, block := range strings.Split(string(buf), "\n\n") {
+		if strings.HasPrefix(block, prefix) {
+			return block
+		}
+	}
+	return ""
+}
+
+// track 执行函数并统计其耗时，执行超出预算时报告慢执行。
+// kind 为监视类型，pc 为函数地址。
+func (l *loom) track(kind int, pc uintptr, fn func()) {
+	w := &l.watches[kind]
+	seq := w.seq.Add(1)
+	start := time.Now()
+	w.pc.Store(pc)
+	w.start.Store(start.UnixMilli())
+	defer func() {
+		w.start.Store(0)
+		elapsed := time.Since(start)
+		l.durations[kind].Observe(elapsed.Seconds())
+		if budget := l.pool.budget; budget > 0 && elapsed >= time.Duration(budget)*time.Millisecond {
+			l.reportSlow(kind, seq, pc, int(elapsed.Milliseconds()), "")
+		}
+	}()
+	fn()
+}
+
+// runTimer 执行定时器回调并统计其耗时，pc 为调用方回调函数的地址。
+func (l *loom) runTimer(pc uintptr, callback func()) { l.track(watchTimer, pc, callback) }
+
+// reportSlow 报告慢执行，同一次执行仅报告一次。
+func (l *loom) reportSlow(kind int, seq uint64, pc uintptr, elapsed int, stack string) {
+	w := &l.watches[kind]
+	for {
+		reported := w.reported.Load()
+		if reported >= seq {
+			return
+		}
+		if w.reported.CompareAndSwap(reported, seq) {
+			break
+		}
+	}
+
+	report := SlowReport{
+		Loom:    l.id,
+		Kind:    watchKinds[kind],
+		Func:    funcName(pc),
+		Elapsed: elapsed,
+		Budget:  l.pool.budget,
+		Stack:   stack,
+	}
+	l.pool.metrics.slow.WithLabelValues(fmt.Sprint(l.id), report.Kind).Inc()
+	if stack == "" {
+		stack = "stack is unavailable as execution has finished."
+	}
+	XLog.Warn("XLoom.Watchdog(%v): slow %v of %v elapsed %vms exceeds budget of %vms.\n%v",
+		l.id, report.Kind, report.Func, report.Elapsed, report.Budget, stack)
+	l.pool.slowHooks.each(func(callback func(SlowReport)) { callback(report) })
+}
+
+// startWatchdog 启动看门狗，周期性地检查线程池的所有线程是否存在超出预算的执行。
+func (p *Pool) startWatchdog(budget int) {
+	stop := make(chan struct{})
+	p.watchdogStop = stop
+	interval := max(budget/4, 1)
+	RunAsync(func() {
+		ticker := time.NewTicker(time.Millisecond * time.Duration(interval))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				now := time.Now().UnixMilli()
+				for _, l := range p.looms() {
+					for kind := range watchCount {
+						w := &l.watches[kind]
+						seq := w.seq.Load()
+						start := w.start.Load()
+						pc := w.pc.Load()
+						if start == 0 || seq != w.seq.Load() || w.reported.Load() >= seq {
+							continue
+						}
+						if elapsed := int(now - start); elapsed >= budget {
+							l.reportSlow(kind, seq, pc, elapsed, goroutineStack(l.gid.Load()))
+						}
+					}
+				}
+			case <-stop:
+				return
+			}
+		}
+	}, true)
+}
+
+// stopWatchdog 停止看门狗。
+func (p *Pool) stopWatchdog() {
+	if p.watchdogStop != nil {
+		close(p.watchdogStop)
+		p.watchdogStop = nil
+	}
+}
+
+// OnSlow 注册默认线程池慢执行的回调，参见 Pool.OnSlow。
+func OnSlow(callback func(report SlowReport)) (unsub func()) { return defaultPool.OnSlow(callback) }
+
+// OnSlow 注册慢执行的回调。
+// callback 为慢执行回调函数，report 为慢执行报告，在看门狗或线程的 goroutine 中调用。
+// 任务、定时器回调或帧更新的执行耗时超出 Loom/Budget 时触发，同一次执行仅触发一次。
+// 返回注销函数，可在任意 goroutine 中调用，重复调用无副作用。
+func (p *Pool) OnSlow(callback func(report SlowReport)) (unsub func()) {
+	if callback == nil {
+		XLog.Critical("XLoom.OnSlow: callback can not be nil.")
+		return func() {}
+	}
+	return p.slowHooks.add(callback)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eframework-org/GO.UTIL/XPrefs"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchdog(t *testing.T) {
+	setup(XPrefs.New().Set(prefsCount, 1).Set(prefsStep, 10).Set(prefsQueue, 1000).Set(prefsBudget, 50))
+	defer setup(XPrefs.Asset())
+
+	var mu sync.Mutex
+	reports := []SlowReport{}
+	unsub := OnSlow(func(report SlowReport) {
+		mu.Lock()
+		reports = append(reports, report)
+		mu.Unlock()
+	})
+	defer unsub()
+
+	t.Run("Task", func(t *testing.T) {
+		mu.Lock()
+		reports = reports[:0]
+		mu.Unlock()
+
+		done := make(chan struct{})
+		RunIn(func() {
+			time.Sleep(time.Millisecond * 200)
+			close(done)
+		}, 0)
+		<-done
+		time.Sleep(time.Millisecond * 50)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if assert.Len(t, reports, 1, "同一次慢执行应当仅报告一次") {
+			report := reports[0]
+			assert.Equal(t, 0, report.Loom)
+			assert.Equal(t, "task", report.Kind)
+			assert.Contains(t, report.Func, "TestWatchdog", "慢执行报告应当包含函数名称")
+			assert.Equal(t, 50, report.Budget)
+			assert.GreaterOrEqual(t, report.Elapsed, 50)
+			assert.Contains(t, report.Stack, "time.Sleep", "看门狗检测的慢执行应当包含线程的堆栈")
+		}
+		assert.Equal(t, 1, int(testutil.ToFloat64(defaultPool.metrics.slow.WithLabelValues("0", "task"))), "慢执行总数应当为 1")
+	})
+
+	t.Run("Timer", func(t *testing.T) {
+		mu.Lock()
+		reports = reports[:0]
+		mu.Unlock()
+
+		done := make(chan struct{})
+		SetTimeout(func() {
+			time.Sleep(time.Millisecond * 200)
+			close(done)
+		}, 0, 0)
+		<-done
+		time.Sleep(time.Millisecond * 50)
+
+		mu.Lock()
+		defer mu.Unlock()
+		kinds := []string{}
+		for _, report := range reports {
+			kinds = append(kinds, report.Kind)
+		}
+		assert.ElementsMatch(t, []string{"timer", "frame"}, kinds, "慢的定时器回调应当同时报告定时器及帧更新")
+	})
+
+	t.Run("Duration", func(t *testing.T) {
+		assert.Greater(t, testutil.CollectAndCount(defaultPool.metrics.duration), 0, "应当导出执行耗时度量")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		assert.NotNil(t, OnSlow(nil), "传入空的回调函数应当返回空的注销函数")
+	})
+}
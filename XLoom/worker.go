@@ -0,0 +1,185 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/eframework-org/GO.UTIL/XLog"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// workerItem 定义了工作池中等待执行的任务。
+type workerItem struct {
+	fn func()    // 任务函数
+	at time.Time // 投递时间，用于统计排队时延
+}
+
+// workerPool 定义了执行阻塞任务的工作池。
+type workerPool struct {
+	size     int                 // 工作者数量
+	mu       sync.RWMutex        // 投递互斥锁，用于保护工作池关闭时的任务投递
+	closed   bool                // 是否已关闭，关闭后不再接收新的任务
+	queue    chan workerItem     // 任务队列
+	latency  prometheus.Observer // 任务排队时延度量
+	busy     atomic.Int64        // 正在执行任务的工作者数量
+	tasks    atomic.Uint64       // 处理总数统计
+	rejected atomic.Uint64       // 拒绝总数统计
+}
+
+// workerCollector 定义了工作池状态的度量采集器，在采集时读取线程池当前工作池的状态。
+type workerCollector struct {
+	pool         *Pool            // 采集的线程池
+	sizeDesc     *prometheus.Desc // 工作者数量度量描述
+	busyDesc     *prometheus.Desc // 忙碌工作者数量度量描述
+	queueDesc    *prometheus.Desc // 队列深度度量描述
+	taskDesc     *prometheus.Desc // 处理总数度量描述
+	rejectedDesc *prometheus.Desc // 拒绝总数度量描述
+}
+
+// newWorkerCollector 创建工作池状态的度量采集器。
+func newWorkerCollector(p *Pool, prefix string) *workerCollector {
+	return &workerCollector{
+		pool:         p,
+		sizeDesc:     prometheus.NewDesc(prefix+"_workers", "Number of workers in pool.", nil, nil),
+		busyDesc:     prometheus.NewDesc(prefix+"_workers_busy", "Number of workers executing tasks.", nil, nil),
+		queueDesc:    prometheus.NewDesc(prefix+"_worker_queue_depth", "Number of tasks waiting in worker queue.", nil, nil),
+		taskDesc:     prometheus.NewDesc(prefix+"_worker_tasks_total", "Total number of tasks processed by worker pool.", nil, nil),
+		rejectedDesc: prometheus.NewDesc(prefix+"_worker_rejected_total", "Total number of tasks rejected by worker pool.", nil, nil),
+	}
+}
+
+// Describe 实现 prometheus.Collector 接口。
+func (c *workerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.sizeDesc
+	ch <- c.busyDesc
+	ch <- c.queueDesc
+	ch <- c.taskDesc
+	ch <- c.rejectedDesc
+}
+
+// Collect 实现 prometheus.Collector 接口。
+func (c *workerCollector) Collect(ch chan<- prometheus.Metric) {
+	w := c.pool.workers.Load()
+	if w == nil {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.sizeDesc, prometheus.GaugeValue, float64(w.size))
+	ch <- prometheus.MustNewConstMetric(c.busyDesc, prometheus.GaugeValue, float64(w.busy.Load()))
+	ch <- prometheus.MustNewConstMetric(c.queueDesc, prometheus.GaugeValue, float64(len(w.queue)))
+	ch <- prometheus.MustNewConstMetric(c.taskDesc, prometheus.CounterValue, float64(w.tasks.Load()))
+	ch <- prometheus.MustNewConstMetric(c.rejectedDesc, prometheus.CounterValue, float64(w.rejected.Load()))
+}
+
+// startWorkers 创建并启动线程池的工作池。
+// size 为工作者数量，queue 为任务队列容量。
+func (p *Pool) startWorkers(size, queue int) {
+	w := &workerPool{size: size, queue: make(chan workerItem, queue), latency: p.metrics.workerLatency}
+	for range size {
+		go w.work()
+	}
+	p.workers.Store(w)
+}
+
+// stopWorkers 关闭线程池当前的工作池，队列中剩余的任务仍将被执行，不等待其执行结束。
+func (p *Pool) stopWorkers() {
+	if w := p.workers.Load(); w != nil {
+		w.mu.Lock()
+		w.closed = true
+		close(w.queue)
+		w.mu.Unlock()
+	}
+}
+
+// work 运行工作者的主循环，直至任务队列关闭。
+func (w *workerPool) work() {
+	for item := range w.queue {
+		w.latency.Observe(time.Since(item.at).Seconds())
+		w.busy.Add(1)
+		item.fn()
+		w.busy.Add(-1)
+		w.tasks.Add(1)
+	}
+}
+
+// submit 投递任务至工作池，工作池已关闭或任务队列已满时返回 false。
+func (w *workerPool) submit(fn func()) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		w.rejected.Add(1)
+		XLog.Critical("XLoom.Offload: worker pool is closed, offload was rejected.")
+		return false
+	}
+	select {
+	case w.queue <- workerItem{fn: fn, at: time.Now()}:
+		return true
+	default:
+		w.rejected.Add(1)
+		XLog.Critical("XLoom.Offload: too many offloads in worker queue.")
+		return false
+	}
+}
+
+// Offload 在工作池中执行阻塞任务，并将结果投递回发起调用的线程。
+// fn 为要执行的阻塞任务，在工作池的 goroutine 中调用，发生的 panic 将转换为 PanicError。
+// then 为可选的结果回调，若在线程中调用 Offload，则通过 RunIn 以普通优先级在该线程中调用，
+// 否则在工作池的 goroutine 中调用；该线程在任务执行期间被 Resize 退役时，投递至接管其任务的线程。
+// 在线程中调用时使用该线程所属线程池的工作池，否则使用默认线程池的工作池。
+// 返回任务是否投递成功，工作池的任务队列已满时返回 false。
+func Offload[T any](fn func() (T, error), then func(result T, err error)) bool {
+	pool := defaultPool
+	if l := currentLoom(); l != nil {
+		pool = l.pool
+	}
+	return OffloadTo(pool, fn, then)
+}
+
+// OffloadTo 在指定线程池的工作池中执行阻塞任务，参数及返回值参见 Offload。
+// pool 为目标线程池，结果回调仅在发起调用的线程属于该线程池时投递回该线程。
+func OffloadTo[T any](pool *Pool, fn func() (T, error), then func(result T, err error)) bool {
+	if fn == nil {
+		XLog.Critical("XLoom.Offload: fn can not be nil.")
+		return false
+	}
+	if pool == nil {
+		XLog.Critical("XLoom.Offload: pool can not be nil.")
+		return false
+	}
+	w := pool.workers.Load()
+	if w == nil {
+		XLog.Critical("XLoom.Offload: worker pool is not initialized.")
+		return false
+	}
+
+	lid := pool.ID()
+	return w.submit(func() {
+		var result T
+		err := protect(func() (err error) {
+			result, err = fn()
+			return
+		})
+		if then == nil {
+			if err != nil {
+				XLog.Error("XLoom.Offload: %v", err)
+			}
+			return
+		}
+		deliver := func() {
+			defer XLog.Caught(false)
+			then(result, err)
+		}
+		if lid < 0 {
+			deliver()
+			return
+		}
+		// 发起调用的线程可能已被 Resize 退役，按照迁移规则投递至接管的线程
+		if l := pool.migrateTarget(lid); l == nil || !runIn(deliver, l, PriorityNormal) {
+			XLog.Critical("XLoom.Offload: deliver result to loom %v failed, result was dropped.", lid)
+		}
+	})
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XLoom
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eframework-org/GO.UTIL/XPrefs"
+	"github.com/petermattis/goid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorker(t *testing.T) {
+	defer setup(XPrefs.Asset())
+
+	t.Run("Loom", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 2).Set(prefsStep, 10).Set(prefsQueue, 1000))
+
+		type result struct {
+			value  int
+			err    error
+			worker int64
+			then   int64
+		}
+		done := make(chan result, 1)
+		RunIn(func() {
+			var worker int64
+			Offload(func() (int, error) {
+				worker = goid.Get()
+				time.Sleep(time.Millisecond * 20) // 模拟阻塞 I/O
+				return 42, nil
+			}, func(value int, err error) {
+				done <- result{value: value, err: err, worker: worker, then: goid.Get()}
+			})
+		}, 1)
+
+		select {
+		case r := <-done:
+			assert.Equal(t, 42, r.value)
+			assert.NoError(t, r.err)
+			assert.NotEqual(t, getLoom(1).gid.Load(), r.worker, "阻塞任务不应当在线程中执行")
+			assert.Equal(t, getLoom(1).gid.Load(), r.then, "结果回调应当在发起调用的线程中执行")
+		case <-time.After(time.Second):
+			t.Fatal("结果回调执行超时")
+		}
+	})
+
+	t.Run("Resize", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 4).Set(prefsStep, 10).Set(prefsQueue, 1000))
+
+		block := make(chan struct{})
+		started := make(chan struct{})
+		done := make(chan int, 1)
+		RunIn(func() {
+			Offload(func() (int, error) {
+				close(started)
+				<-block
+				return 42, nil
+			}, func(int, error) { done <- ID() })
+		}, 3)
+		<-started
+		Resize(2)
+		close(block)
+
+		select {
+		case lid := <-done:
+			assert.Equal(t, 1, lid, "发起调用的线程退役后结果回调应当在接管的线程中执行")
+		case <-time.After(time.Second):
+			t.Fatal("发起调用的线程退役后结果回调未执行")
+		}
+	})
+
+	t.Run("Async", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 1).Set(prefsStep, 10).Set(prefsQueue, 1000))
+
+		expected := errors.New("failed")
+		errs := make(chan error, 2)
+		assert.True(t, Offload(func() (int, error) { return 0, expected }, func(_ int, err error) { errs <- err }))
+		assert.True(t, Offload(func() (int, error) { panic("boom") }, func(_ int, err error) { errs <- err }))
+
+		got := []error{<-errs, <-errs}
+		assert.Contains(t, got, expected, "阻塞任务的错误应当传递至结果回调")
+		var perr *PanicError
+		if !errors.As(got[0], &perr) {
+			assert.ErrorAs(t, got[1], &perr, "阻塞任务的 panic 应当转换为 PanicError")
+		}
+	})
+
+	t.Run("Queue", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 1).Set(prefsStep, 10).Set(prefsQueue, 1000).
+			Set(prefsWorkers, 1).Set(prefsWorkerQueue, 1))
+
+		block := make(chan struct{})
+		started := make(chan struct{})
+		assert.True(t, Offload(func() (any, error) {
+			close(started)
+			<-block
+			return nil, nil
+		}, nil))
+		<-started
+		assert.True(t, Offload(func() (any, error) { return nil, nil }, nil), "任务队列未满时应当投递成功")
+		assert.False(t, Offload(func() (any, error) { return nil, nil }, nil), "任务队列已满时应当投递失败")
+
+		assert.Equal(t, 1.0, gather(t, "xloom_workers"), "工作者数量应当为 1")
+		assert.Equal(t, 1.0, gather(t, "xloom_workers_busy"), "正在执行任务的工作者数量应当为 1")
+		assert.Equal(t, 1.0, gather(t, "xloom_worker_queue_depth"), "等待执行的任务数量应当为 1")
+		assert.Equal(t, 1.0, gather(t, "xloom_worker_rejected_total"), "拒绝总数应当为 1")
+
+		close(block)
+		assert.Eventually(t, func() bool { return gather(t, "xloom_worker_tasks_total") == 2 },
+			time.Second, time.Millisecond*10, "处理总数应当为 2")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		setup(XPrefs.New().Set(prefsCount, 1).Set(prefsStep, 10).Set(prefsQueue, 1000))
+
+		assert.False(t, Offload[int](nil, nil), "空的阻塞任务应当投递失败")
+		assert.Panics(t, func() {
+			setup(XPrefs.New().Set(prefsWorkers, 0))
+		}, "工作者数量为 0 时应当抛出异常")
+	})
+}
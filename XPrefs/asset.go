@@ -6,67 +6,62 @@ package XPrefs
 
 import (
 	"fmt"
-	"strings"
 )
 
 // 资产首选项的默认路径.
 const assetFile = "Assets/Preferences.json"
 
 // 管理只读的资产首选项.
-type prefsAsset struct{ prefsBase }
+type prefsAsset struct {
+	prefsBase
+	watch    fileWatch    // 资产首选项文件的变更检测.
+	profiles []*fileWatch // 叠加配置文件的变更检测.
+}
 
 // read 函数从指定的文件中读取偏好设置。
-// 如果没有指定文件，则从默认的资产文件中读取。
+// 如果没有指定文件，则从默认的资产文件中读取，默认的 JSON 文件不存在时依次查找 YAML 和 TOML 格式的文件。
+// 根据文件扩展名识别 JSON、YAML（.yaml、.yml）或 TOML（.toml）格式。
+// 读取后依次深度合并同目录下运行模式及渠道的叠加配置文件，如 Preferences.Prod.json、Preferences.Steam.json，参见 layer。
 // 如果文件读取成功，则返回 true，否则返回 false。
 func (pa *prefsAsset) read(file ...string) bool {
 	var data []byte
 	var err error
-	filename := assetFile
+	filename := locate(assetFile)
 
 	if len(file) > 0 && fileExists(file[0]) {
 		filename = file[0]
-	} else if !fileExists(assetFile) {
+	} else if !fileExists(filename) {
 		fmt.Printf("XPrefs.Asset.Read: file %s was not found.\n", assetFile)
 		return false
 	}
 
+	pa.watch.mark(filename)
 	data, err = readFile(filename)
 	if err != nil {
 		fmt.Printf("XPrefs.Asset.Read: failed to read file %s: %v\n", filename, err)
 		return false
 	}
 	fmt.Printf("XPrefs.Asset.Read: reading %s.\n", filename)
+	if data, err = decode(filename, data); err != nil {
+		fmt.Printf("XPrefs.Asset.Read: failed to decode file %s: %v\n", filename, err)
+		return false
+	}
+	data, origins, files, err := layer(filename, data, pa.overrides())
+	if err != nil {
+		fmt.Printf("XPrefs.Asset.Read: failed to layer file %s: %v\n", filename, err)
+		return false
+	}
+	pa.profiles = watchFiles(files)
+	pa.origins = origins
 
 	return pa.parse(data)
 }
 
-// parse 函数解析配置数据并应用命令行参数覆盖。
+// parse 函数解析配置数据并应用环境变量及命令行参数覆盖。
 // 输入字节数组形式的配置数据，解析成功返回 true，失败返回 false。
-// 解析完成后会检查命令行参数中以 "Prefs@Asset." 开头的配置项，并用其值覆盖相应的配置。
+// 解析完成后会检查以 "XPREFS_ASSET__" 开头的环境变量及以 "Prefs@Asset." 开头的命令行参数，并用其值覆盖相应的配置。
 func (pa *prefsAsset) parse(data []byte) bool {
-	defer func() {
-		args := parseArgs()
-		for k, v := range args {
-			if strings.HasPrefix(k, "Prefs@Asset.") {
-				key := strings.TrimPrefix(k, "Prefs@Asset.")
-				if strings.Contains(key, ".") {
-					parts := strings.Split(key, ".")
-					current := (any)(pa).(IBase)
-					for i := 0; i < len(parts)-1; i++ {
-						part := parts[i]
-						if !current.Has(part) {
-							current.Set(part, New())
-						}
-						current = current.Get(part).(IBase)
-					}
-					current.Set(parts[len(parts)-1], v)
-				} else {
-					pa.Set(key, v)
-				}
-				fmt.Printf("XPrefs.Asset.Parse: override %s = %s\n", key, v)
-			}
-		}
-	}()
+	defer override(pa, "Asset", pa.overrides())
 
 	return pa.prefsBase.parse(data)
 }
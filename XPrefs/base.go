@@ -8,7 +8,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
-	"strconv"
 	"strings"
 	"sync"
 
@@ -33,6 +32,22 @@ type IBase interface {
 	// 输入要删除的键名，删除完成后返回接口实例本身，支持链式调用。
 	Unset(key string) IBase
 
+	// HasPath 检查配置路径对应的配置项是否存在。
+	// 路径使用 . 分隔各级配置，键名中的 . 和 \ 需转义为 \. 和 \\，数组的元素使用数字索引，如 Log/File.Level。
+	HasPath(path string) bool
+
+	// GetPath 获取配置路径对应的配置项的值。
+	// 输入配置路径和可选的默认值，返回配置项的值，如果配置项不存在且未提供默认值则返回 nil。
+	GetPath(path string, defval ...any) any
+
+	// SetPath 设置配置路径对应的配置项的值，不存在的上级配置将被创建。
+	// 输入配置路径和任意类型的值，设置完成后返回接口实例本身，支持链式调用。
+	SetPath(path string, value any) IBase
+
+	// UnsetPath 删除配置路径对应的配置项。
+	// 输入要删除的配置路径，删除完成后返回接口实例本身，支持链式调用。
+	UnsetPath(path string) IBase
+
 	// Get 获取配置项的值。
 	// 输入键名和可选的默认值，返回配置项的值，如果配置项不存在且未提供默认值则返回 nil。
 	Get(key string, defval ...any) any
@@ -45,34 +60,66 @@ type IBase interface {
 	// 输入键名和可选的默认值，返回配置项的整数值，如果配置项不存在或类型转换失败且未提供默认值则返回 0。
 	GetInt(key string, defval ...int) int
 
+	// TryInt 获取配置项的值并转换为 int 类型，转换规则与 GetInt 一致。
+	// 配置项不存在时返回包装了 ErrNotFound 的错误，类型转换失败时返回转换的错误。
+	TryInt(key string) (int, error)
+
 	// GetInts 获取配置项的整数数组。
 	// 输入键名和可选的默认值数组，返回配置项的整数数组，如果配置项不存在或类型转换失败且未提供默认值则返回 nil。
 	GetInts(key string, defval ...[]int) []int
 
+	// TryInts 获取配置项的值并转换为 []int 类型，转换规则与 GetInts 一致。
+	// 配置项不存在时返回包装了 ErrNotFound 的错误，类型转换失败时返回转换的错误。
+	TryInts(key string) ([]int, error)
+
 	// GetFloat 获取配置项的浮点数值。
 	// 输入键名和可选的默认值，返回配置项的浮点数值，如果配置项不存在或类型转换失败且未提供默认值则返回 0。
 	GetFloat(key string, defval ...float32) float32
 
+	// TryFloat 获取配置项的值并转换为 float32 类型，转换规则与 GetFloat 一致。
+	// 配置项不存在时返回包装了 ErrNotFound 的错误，类型转换失败时返回转换的错误。
+	TryFloat(key string) (float32, error)
+
 	// GetFloats 获取配置项的浮点数数组。
 	// 输入键名和可选的默认值数组，返回配置项的浮点数数组，如果配置项不存在或类型转换失败且未提供默认值则返回 nil。
 	GetFloats(key string, defval ...[]float32) []float32
 
+	// TryFloats 获取配置项的值并转换为 []float32 类型，转换规则与 GetFloats 一致。
+	// 配置项不存在时返回包装了 ErrNotFound 的错误，类型转换失败时返回转换的错误。
+	TryFloats(key string) ([]float32, error)
+
 	// GetBool 获取配置项的布尔值。
 	// 输入键名和可选的默认值，返回配置项的布尔值，如果配置项不存在或类型转换失败且未提供默认值则返回 false。
 	GetBool(key string, defval ...bool) bool
 
+	// TryBool 获取配置项的值并转换为 bool 类型，转换规则与 GetBool 一致。
+	// 配置项不存在时返回包装了 ErrNotFound 的错误，类型转换失败时返回转换的错误。
+	TryBool(key string) (bool, error)
+
 	// GetBools 获取配置项的布尔值数组。
 	// 输入键名和可选的默认值数组，返回配置项的布尔值数组，如果配置项不存在或类型转换失败且未提供默认值则返回 nil。
 	GetBools(key string, defval ...[]bool) []bool
 
+	// TryBools 获取配置项的值并转换为 []bool 类型，转换规则与 GetBools 一致。
+	// 配置项不存在时返回包装了 ErrNotFound 的错误，类型转换失败时返回转换的错误。
+	TryBools(key string) ([]bool, error)
+
 	// GetString 获取配置项的字符串值。
 	// 输入键名和可选的默认值，返回配置项的字符串值，如果配置项不存在或类型转换失败且未提供默认值则返回空字符串。
 	GetString(key string, defval ...string) string
 
+	// TryString 获取配置项的值并转换为 string 类型，转换规则与 GetString 一致。
+	// 配置项不存在时返回包装了 ErrNotFound 的错误，类型转换失败时返回转换的错误。
+	TryString(key string) (string, error)
+
 	// GetStrings 获取配置项的字符串数组。
 	// 输入键名和可选的默认值数组，返回配置项的字符串数组，如果配置项不存在或类型转换失败且未提供默认值则返回 nil。
 	GetStrings(key string, defval ...[]string) []string
 
+	// TryStrings 获取配置项的值并转换为 []string 类型，转换规则与 GetStrings 一致。
+	// 配置项不存在时返回包装了 ErrNotFound 的错误，类型转换失败时返回转换的错误。
+	TryStrings(key string) ([]string, error)
+
 	// Json 将配置内容转换为 JSON 字符串。
 	// 输入可选的格式化参数，如果为 true 则返回格式化后的 JSON 字符串，默认返回压缩的 JSON 字符串。
 	Json(pretty ...bool) string
@@ -85,10 +132,12 @@ type IBase interface {
 // prefsBase 实现了 IBase 接口，提供配置管理的基础功能。
 // 支持并发安全的配置项读写、类型转换、多级配置和变量引用等功能。
 type prefsBase struct {
-	sync.RWMutex                // 读写锁，用于保证并发操作的安全性
-	pairs        map[string]any // 存储配置项的键值对映射
-	npairs       map[string]any // 存储多级配置的缓存，避免重复解析
-	keys         []string       // 存储所有配置项的键名，用于快速遍历
+	sync.RWMutex                   // 读写锁，用于保证并发操作的安全性
+	pairs        map[string]any    // 存储配置项的键值对映射
+	npairs       map[string]any    // 存储多级配置的缓存，避免重复解析
+	keys         []string          // 存储所有配置项的键名，用于快速遍历
+	origins      map[string]string // 存储配置项的来源，参见 Explain
+	ovr          *overrides        // 覆盖配置项的命令行参数及环境变量，为空时在解析时读取
 }
 
 // Keys 返回所有配置项的键名列表。
@@ -130,7 +179,7 @@ func (pb *prefsBase) Has(key string) bool {
 
 // Set 设置配置项的值。
 // 输入键名和任意类型的值，将其存储在配置项映射中。
-// 如果键不存在，会将其添加到键名列表中。
+// 如果键不存在，会将其添加到键名列表中；多级配置的缓存将被删除，以保证 Get 返回新的值。
 // 返回接口实例本身，支持链式调用。
 // 此方法是并发安全的，使用写锁保护。
 func (pb *prefsBase) Set(key string, value any) IBase {
@@ -142,10 +191,11 @@ func (pb *prefsBase) Set(key string, value any) IBase {
 		pb.keys = make([]string, 0, 8)
 	}
 
-	if _, exists := pb.pairs[key]; !exists {
+	if _, exists := pb.pairs[key]; !exists && pb.keys != nil {
 		pb.keys = append(pb.keys, key)
 	}
 	pb.pairs[key] = value
+	delete(pb.npairs, key)
 	return pb
 }
 
@@ -166,6 +216,7 @@ func (pb *prefsBase) Unset(key string) IBase {
 
 	if _, exists := pb.pairs[key]; exists {
 		delete(pb.pairs, key)
+		untrace(pb.origins, EscapeKey(key))
 		for i, k := range pb.keys {
 			if k == key {
 				pb.keys = append(pb.keys[:i], pb.keys[i+1:]...)
@@ -253,47 +304,12 @@ func (pb *prefsBase) Gets(key string, defval ...[]any) []any {
 }
 
 // GetInt 获取配置项的整数值。
-// 输入键名和可选的默认值。支持多种数值类型的自动转换，包括：
-// - 各种整数类型（int8 到 int64，uint8 到 uint64）
-// - 浮点数类型（float32，float64）
-// - 字符串类型（会尝试解析为整数）
-// 如果转换失败且提供了默认值，则返回默认值；否则返回 0。
-// 此方法是并发安全的，使用写锁保护。
+// 输入键名和可选的默认值。支持整数、浮点数（截断小数部分）及可解析为数值的字符串。
+// 如果配置项不存在或类型转换失败且提供了默认值，则返回默认值；否则返回 0。
+// 此方法是并发安全的，使用读锁保护。
 func (pb *prefsBase) GetInt(key string, defval ...int) int {
-	pb.Lock()
-	defer pb.Unlock()
-
-	if val, exists := pb.pairs[key]; exists {
-		switch v := val.(type) {
-		case int:
-			return v
-		case int8:
-			return int(v)
-		case int16:
-			return int(v)
-		case int32:
-			return int(v)
-		case int64:
-			return int(v)
-		case uint:
-			return int(v)
-		case uint8:
-			return int(v)
-		case uint16:
-			return int(v)
-		case uint32:
-			return int(v)
-		case uint64:
-			return int(v)
-		case float32:
-			return int(v)
-		case float64:
-			return int(v)
-		case string:
-			if intVal, err := strconv.Atoi(v); err == nil {
-				return intVal
-			}
-		}
+	if val, err := pb.TryInt(key); err == nil {
+		return val
 	}
 	if len(defval) > 0 {
 		return defval[0]
@@ -301,55 +317,20 @@ func (pb *prefsBase) GetInt(key string, defval ...int) int {
 	return 0
 }
 
+// TryInt 获取配置项的整数值，类型转换的规则与 GetInt 一致。
+// 配置项不存在时返回包装了 ErrNotFound 的错误，类型转换失败时返回转换的错误。
+// 此方法是并发安全的，使用读锁保护。
+func (pb *prefsBase) TryInt(key string) (int, error) {
+	return tryGet(pb, key, coerceInt)
+}
+
 // GetInts 获取配置项的整数数组。
-// 输入键名和可选的默认值数组。支持处理原生整数数组和任意类型数组。
-// 对于任意类型数组，会尝试将每个元素转换为整数，支持与 GetInt 相同的类型转换。
-// 如果键不存在或转换失败且提供了默认值数组，则返回默认值数组。
-// 此方法是并发安全的，使用写锁保护。
+// 输入键名和可选的默认值。支持任意类型的数组及 JSON 数组格式的字符串，元素的转换与 GetInt 一致。
+// 如果配置项不存在或类型转换失败且提供了默认值，则返回默认值；否则返回 nil。
+// 此方法是并发安全的，使用读锁保护。
 func (pb *prefsBase) GetInts(key string, defval ...[]int) []int {
-	pb.Lock()
-	defer pb.Unlock()
-
-	if val, exists := pb.pairs[key]; exists {
-		switch v := val.(type) {
-		case []int:
-			return v
-		case []any:
-			intSlice := make([]int, len(v))
-			for i, vv := range v {
-				switch ve := vv.(type) {
-				case int:
-					intSlice[i] = ve
-				case int8:
-					intSlice[i] = int(ve)
-				case int16:
-					intSlice[i] = int(ve)
-				case int32:
-					intSlice[i] = int(ve)
-				case int64:
-					intSlice[i] = int(ve)
-				case uint:
-					intSlice[i] = int(ve)
-				case uint8:
-					intSlice[i] = int(ve)
-				case uint16:
-					intSlice[i] = int(ve)
-				case uint32:
-					intSlice[i] = int(ve)
-				case uint64:
-					intSlice[i] = int(ve)
-				case float32:
-					intSlice[i] = int(ve)
-				case float64:
-					intSlice[i] = int(ve)
-				case string:
-					if iv, err := strconv.Atoi(ve); err == nil {
-						intSlice[i] = iv
-					}
-				}
-			}
-			return intSlice
-		}
+	if val, err := pb.TryInts(key); err == nil {
+		return val
 	}
 	if len(defval) > 0 {
 		return defval[0]
@@ -357,48 +338,20 @@ func (pb *prefsBase) GetInts(key string, defval ...[]int) []int {
 	return nil
 }
 
+// TryInts 获取配置项的整数数组，类型转换的规则与 GetInts 一致。
+// 配置项不存在时返回包装了 ErrNotFound 的错误，类型转换失败时返回转换的错误。
+// 此方法是并发安全的，使用读锁保护。
+func (pb *prefsBase) TryInts(key string) ([]int, error) {
+	return tryGet(pb, key, func(v any) ([]int, error) { return coerceSlice(v, sealed(coerceInt)) })
+}
+
 // GetFloat 获取配置项的浮点数值。
-// 输入键名和可选的默认值。支持多种数值类型的自动转换，包括：
-// - 各种整数类型（int8 到 int64，uint8 到 uint64）
-// - 浮点数类型（float32，float64）
-// - 字符串类型（会尝试解析为浮点数）
-// 如果转换失败且提供了默认值，则返回默认值；否则返回 0。
-// 此方法是并发安全的，使用写锁保护。
+// 输入键名和可选的默认值。支持整数、浮点数及可解析为数值的字符串。
+// 如果配置项不存在或类型转换失败且提供了默认值，则返回默认值；否则返回 0。
+// 此方法是并发安全的，使用读锁保护。
 func (pb *prefsBase) GetFloat(key string, defval ...float32) float32 {
-	pb.Lock()
-	defer pb.Unlock()
-
-	if val, exists := pb.pairs[key]; exists {
-		switch v := val.(type) {
-		case int:
-			return float32(v)
-		case int8:
-			return float32(v)
-		case int16:
-			return float32(v)
-		case int32:
-			return float32(v)
-		case int64:
-			return float32(v)
-		case uint:
-			return float32(v)
-		case uint8:
-			return float32(v)
-		case uint16:
-			return float32(v)
-		case uint32:
-			return float32(v)
-		case uint64:
-			return float32(v)
-		case float32:
-			return float32(v)
-		case float64:
-			return float32(v)
-		case string:
-			if nval, err := strconv.ParseFloat(v, 32); err == nil {
-				return float32(nval)
-			}
-		}
+	if val, err := pb.TryFloat(key); err == nil {
+		return val
 	}
 	if len(defval) > 0 {
 		return defval[0]
@@ -406,28 +359,20 @@ func (pb *prefsBase) GetFloat(key string, defval ...float32) float32 {
 	return 0
 }
 
+// TryFloat 获取配置项的浮点数值，类型转换的规则与 GetFloat 一致。
+// 配置项不存在时返回包装了 ErrNotFound 的错误，类型转换失败时返回转换的错误。
+// 此方法是并发安全的，使用读锁保护。
+func (pb *prefsBase) TryFloat(key string) (float32, error) {
+	return tryGet(pb, key, coerceFloat32)
+}
+
 // GetFloats 获取配置项的浮点数数组。
-// 输入键名和可选的默认值数组。支持处理原生浮点数数组和任意类型数组。
-// 对于任意类型数组，会尝试将每个元素转换为浮点数。
-// 如果键不存在或转换失败且提供了默认值数组，则返回默认值数组。
-// 此方法是并发安全的，使用写锁保护。
+// 输入键名和可选的默认值。支持任意类型的数组及 JSON 数组格式的字符串，元素的转换与 GetFloat 一致。
+// 如果配置项不存在或类型转换失败且提供了默认值，则返回默认值；否则返回 nil。
+// 此方法是并发安全的，使用读锁保护。
 func (pb *prefsBase) GetFloats(key string, defval ...[]float32) []float32 {
-	pb.Lock()
-	defer pb.Unlock()
-
-	if val, exists := pb.pairs[key]; exists {
-		switch v := val.(type) {
-		case []float32:
-			return v
-		case []any:
-			floatSlice := make([]float32, len(v))
-			for i, item := range v {
-				if floatVal, ok := item.(float64); ok { // JSON numbers are float64
-					floatSlice[i] = float32(floatVal)
-				}
-			}
-			return floatSlice
-		}
+	if val, err := pb.TryFloats(key); err == nil {
+		return val
 	}
 	if len(defval) > 0 {
 		return defval[0]
@@ -435,18 +380,20 @@ func (pb *prefsBase) GetFloats(key string, defval ...[]float32) []float32 {
 	return nil
 }
 
+// TryFloats 获取配置项的浮点数数组，类型转换的规则与 GetFloats 一致。
+// 配置项不存在时返回包装了 ErrNotFound 的错误，类型转换失败时返回转换的错误。
+// 此方法是并发安全的，使用读锁保护。
+func (pb *prefsBase) TryFloats(key string) ([]float32, error) {
+	return tryGet(pb, key, func(v any) ([]float32, error) { return coerceSlice(v, sealed(coerceFloat32)) })
+}
+
 // GetBool 获取配置项的布尔值。
-// 输入键名和可选的默认值。仅支持原生布尔类型的值。
-// 如果值不是布尔类型且提供了默认值，则返回默认值；否则返回 false。
-// 此方法是并发安全的，使用写锁保护。
+// 输入键名和可选的默认值。支持布尔值、数值 0 和 1 及不区分大小写的 true/false、1/0、yes/no、y/n、on/off 字符串。
+// 如果配置项不存在或类型转换失败且提供了默认值，则返回默认值；否则返回 false。
+// 此方法是并发安全的，使用读锁保护。
 func (pb *prefsBase) GetBool(key string, defval ...bool) bool {
-	pb.Lock()
-	defer pb.Unlock()
-
-	if val, exists := pb.pairs[key]; exists {
-		if boolVal, ok := val.(bool); ok {
-			return boolVal
-		}
+	if val, err := pb.TryBool(key); err == nil {
+		return val
 	}
 	if len(defval) > 0 {
 		return defval[0]
@@ -454,28 +401,20 @@ func (pb *prefsBase) GetBool(key string, defval ...bool) bool {
 	return false
 }
 
+// TryBool 获取配置项的布尔值，类型转换的规则与 GetBool 一致。
+// 配置项不存在时返回包装了 ErrNotFound 的错误，类型转换失败时返回转换的错误。
+// 此方法是并发安全的，使用读锁保护。
+func (pb *prefsBase) TryBool(key string) (bool, error) {
+	return tryGet(pb, key, coerceBool)
+}
+
 // GetBools 获取配置项的布尔值数组。
-// 输入键名和可选的默认值数组。支持处理原生布尔值数组和任意类型数组。
-// 对于任意类型数组，会尝试将每个元素转换为布尔值。
-// 如果键不存在或转换失败且提供了默认值数组，则返回默认值数组。
-// 此方法是并发安全的，使用写锁保护。
+// 输入键名和可选的默认值。支持任意类型的数组及 JSON 数组格式的字符串，元素的转换与 GetBool 一致。
+// 如果配置项不存在或类型转换失败且提供了默认值，则返回默认值；否则返回 nil。
+// 此方法是并发安全的，使用读锁保护。
 func (pb *prefsBase) GetBools(key string, defval ...[]bool) []bool {
-	pb.Lock()
-	defer pb.Unlock()
-
-	if val, exists := pb.pairs[key]; exists {
-		switch v := val.(type) {
-		case []bool:
-			return v
-		case []any:
-			boolSlice := make([]bool, len(v))
-			for i, item := range v {
-				if boolVal, ok := item.(bool); ok {
-					boolSlice[i] = boolVal
-				}
-			}
-			return boolSlice
-		}
+	if val, err := pb.TryBools(key); err == nil {
+		return val
 	}
 	if len(defval) > 0 {
 		return defval[0]
@@ -483,18 +422,20 @@ func (pb *prefsBase) GetBools(key string, defval ...[]bool) []bool {
 	return nil
 }
 
+// TryBools 获取配置项的布尔值数组，类型转换的规则与 GetBools 一致。
+// 配置项不存在时返回包装了 ErrNotFound 的错误，类型转换失败时返回转换的错误。
+// 此方法是并发安全的，使用读锁保护。
+func (pb *prefsBase) TryBools(key string) ([]bool, error) {
+	return tryGet(pb, key, func(v any) ([]bool, error) { return coerceSlice(v, sealed(coerceBool)) })
+}
+
 // GetString 获取配置项的字符串值。
-// 输入键名和可选的默认值。仅支持原生字符串类型的值。
-// 如果值不是字符串类型且提供了默认值，则返回默认值；否则返回空字符串。
-// 此方法是并发安全的，使用写锁保护。
+// 输入键名和可选的默认值。支持字符串、数值及布尔值，数值按照最短的十进制格式转换。
+// 如果配置项不存在或类型转换失败且提供了默认值，则返回默认值；否则返回 空字符串。
+// 此方法是并发安全的，使用读锁保护。
 func (pb *prefsBase) GetString(key string, defval ...string) string {
-	pb.Lock()
-	defer pb.Unlock()
-
-	if val, exists := pb.pairs[key]; exists {
-		if strVal, ok := val.(string); ok {
-			return strVal
-		}
+	if val, err := pb.TryString(key); err == nil {
+		return val
 	}
 	if len(defval) > 0 {
 		return defval[0]
@@ -502,28 +443,20 @@ func (pb *prefsBase) GetString(key string, defval ...string) string {
 	return ""
 }
 
+// TryString 获取配置项的字符串值，类型转换的规则与 GetString 一致。
+// 配置项不存在时返回包装了 ErrNotFound 的错误，类型转换失败时返回转换的错误。
+// 此方法是并发安全的，使用读锁保护。
+func (pb *prefsBase) TryString(key string) (string, error) {
+	return tryGet(pb, key, coerceString)
+}
+
 // GetStrings 获取配置项的字符串数组。
-// 输入键名和可选的默认值数组。支持处理原生字符串数组和任意类型数组。
-// 对于任意类型数组，会尝试将每个元素转换为字符串。
-// 如果键不存在或转换失败且提供了默认值数组，则返回默认值数组。
-// 此方法是并发安全的，使用写锁保护。
+// 输入键名和可选的默认值。支持任意类型的数组及 JSON 数组格式的字符串，元素的转换与 GetString 一致。
+// 如果配置项不存在或类型转换失败且提供了默认值，则返回默认值；否则返回 nil。
+// 此方法是并发安全的，使用读锁保护。
 func (pb *prefsBase) GetStrings(key string, defval ...[]string) []string {
-	pb.Lock()
-	defer pb.Unlock()
-
-	if val, exists := pb.pairs[key]; exists {
-		switch v := val.(type) {
-		case []string:
-			return v
-		case []any:
-			strSlice := make([]string, len(v))
-			for i, item := range v {
-				if strVal, ok := item.(string); ok {
-					strSlice[i] = strVal
-				}
-			}
-			return strSlice
-		}
+	if val, err := pb.TryStrings(key); err == nil {
+		return val
 	}
 	if len(defval) > 0 {
 		return defval[0]
@@ -531,6 +464,31 @@ func (pb *prefsBase) GetStrings(key string, defval ...[]string) []string {
 	return nil
 }
 
+// TryStrings 获取配置项的字符串数组，类型转换的规则与 GetStrings 一致。
+// 配置项不存在时返回包装了 ErrNotFound 的错误，类型转换失败时返回转换的错误。
+// 此方法是并发安全的，使用读锁保护。
+func (pb *prefsBase) TryStrings(key string) ([]string, error) {
+	return tryGet(pb, key, func(v any) ([]string, error) { return coerceSlice(v, sealed(coerceString)) })
+}
+
+// tryGet 获取配置项的值并使用 coerce 转换类型。
+// 配置项不存在时返回包装了 ErrNotFound 的错误，类型转换失败时返回包含键名的错误。
+func tryGet[T any](pb *prefsBase, key string, coerce func(any) (T, error)) (T, error) {
+	pb.RLock()
+	val, exists := pb.pairs[key]
+	pb.RUnlock()
+
+	var zero T
+	if !exists {
+		return zero, notFound(key)
+	}
+	ret, err := sealed(coerce)(val)
+	if err != nil {
+		return zero, malformed(key, err)
+	}
+	return ret, nil
+}
+
 // Json 将配置内容转换为 JSON 字符串。
 // 输入可选的格式化参数，如果为 true 则返回格式化后的 JSON 字符串。
 // 如果未提供格式化参数或为 false，则返回压缩的 JSON 字符串。
@@ -543,6 +501,13 @@ func (pb *prefsBase) Json(pretty ...bool) string {
 	return ret
 }
 
+// MarshalJSON 实现了 json.Marshaler 接口，使得多级配置中的配置实例可以被正确地序列化。
+func (pb *prefsBase) MarshalJSON() ([]byte, error) {
+	pb.RLock()
+	defer pb.RUnlock()
+	return json.Marshal(pb.pairs)
+}
+
 // Eval 计算包含配置项引用的字符串表达式。
 // 输入包含配置项引用的字符串表达式，格式为 ${Prefs.key}。
 // 支持以下特性：
@@ -550,6 +515,7 @@ func (pb *prefsBase) Json(pretty ...bool) string {
 // - 循环引用检测，避免死循环
 // - 嵌套变量引用检测
 // - 未定义变量和空值处理
+// - 加密的配置项保持 ENC(...) 格式，不会被解密，避免明文出现在拼接的字符串中
 // 返回计算后的结果字符串，对于特殊情况会添加相应的标记。
 func (pb *prefsBase) Eval(input string) string {
 	pattern := regexp.MustCompile(`\$\{Prefs\.([^}]+?)\}`)
@@ -572,31 +538,11 @@ func (pb *prefsBase) Eval(input string) string {
 		defer delete(visited, path)
 
 		// 3. 获取变量值（支持多级路径）
-		var value string
-		if strings.Contains(path, ".") {
-			parts := strings.Split(path, ".")
-			current := (any)(pb).(IBase)
-			for i := 0; i < len(parts)-1; i++ {
-				if !current.Has(parts[i]) {
-					return fmt.Sprintf("${Prefs.%v}(Unknown)", path)
-				}
-				next := current.Get(parts[i])
-				if next == nil {
-					return fmt.Sprintf("${Prefs.%v}(Unknown)", path)
-				}
-				if base, ok := next.(IBase); ok {
-					current = base
-				} else {
-					return fmt.Sprintf("${Prefs.%v}(Unknown)", path)
-				}
-			}
-			value = current.GetString(parts[len(parts)-1])
-		} else {
-			if !pb.Has(path) {
-				return fmt.Sprintf("${Prefs.%v}(Unknown)", path)
-			}
-			value = pb.GetString(path)
+		raw, ok := pb.lookupPath(path)
+		if !ok {
+			return fmt.Sprintf("${Prefs.%v}(Unknown)", path)
 		}
+		value, _ := coerceString(raw)
 
 		// 4. 检查空值
 		if value == "" {
@@ -610,35 +556,21 @@ func (pb *prefsBase) Eval(input string) string {
 	return pattern.ReplaceAllStringFunc(input, replaceFunc)
 }
 
-// parse 解析配置数据并应用命令行参数覆盖。
+// overrides 返回覆盖配置项的命令行参数及环境变量，未指定时读取当前的命令行参数及环境变量。
+func (pb *prefsBase) overrides() *overrides {
+	if pb.ovr != nil {
+		return pb.ovr
+	}
+	return readOverrides()
+}
+
+// parse 解析配置数据并应用环境变量及命令行参数覆盖。
 // 输入字节数组形式的配置数据，将其解析为配置项映射。
-// 解析完成后会检查命令行参数中以 "Prefs." 开头的配置项，并用其值覆盖相应的配置。
-// 支持多级配置的命令行参数覆盖。
+// 解析完成后会检查以 "XPREFS__" 开头的环境变量及以 "Prefs." 开头的命令行参数，并用其值覆盖相应的配置。
+// 支持多级配置的覆盖，命令行参数的优先级高于环境变量。
 // 返回 true 表示解析成功，false 表示解析失败。
 func (pb *prefsBase) parse(data []byte) bool {
-	defer func() {
-		args := parseArgs()
-		for k, v := range args {
-			if strings.HasPrefix(k, "Prefs.") {
-				key := strings.TrimPrefix(k, "Prefs.")
-				if strings.Contains(key, ".") {
-					parts := strings.Split(key, ".")
-					current := (any)(pb).(IBase)
-					for i := 0; i < len(parts)-1; i++ {
-						part := parts[i]
-						if !current.Has(part) {
-							current.Set(part, New())
-						}
-						current = current.Get(part).(IBase)
-					}
-					current.Set(parts[len(parts)-1], v)
-				} else {
-					pb.Set(key, v)
-				}
-				fmt.Printf("XPrefs.Base.Parse: override %s = %s\n", key, v)
-			}
-		}
-	}()
+	defer override(pb, "", pb.overrides())
 
 	if data == nil || len(data) == 0 {
 		fmt.Printf("XPrefs.Base.Parse: nil data\n")
@@ -0,0 +1,350 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XPrefs
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Decoder 定义了自定义的配置解码接口。
+// 绑定时字段的指针实现了该接口，则由其自行解码配置项的原始值。
+type Decoder interface {
+	// DecodePrefs 解码配置项的原始值，value 可能为基础类型、数组或 IBase 配置实例。
+	DecodePrefs(value any) error
+}
+
+var (
+	// decoderType Decoder 接口的反射类型。
+	decoderType = reflect.TypeOf((*Decoder)(nil)).Elem()
+
+	// textUnmarshalerType encoding.TextUnmarshaler 接口的反射类型。
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+	// durationType time.Duration 的反射类型。
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// Bind 将配置绑定至结构体。
+// prefs 为配置源，target 为结构体的指针，字段通过以下标签声明绑定规则：
+//   - prefs:"Loom/Count"：配置项的键名，未指定时使用字段名称，为 "-" 时忽略该字段；追加 ",optional" 表示配置项可以不存在
+//   - default:"1"：配置项不存在时的默认值，数组使用逗号分隔
+//
+// 支持基础类型、time.Duration（字符串如 "5s"，数值视为毫秒）、结构体（对应多级配置）、数组（包括多级配置的数组）、
+// 以字符串为键的映射、指针及实现了 Decoder 或 encoding.TextUnmarshaler 的类型。
+// 返回所有缺失或无效的字段合并后的错误，绑定成功时返回 nil。
+func Bind(prefs IBase, target any) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("XPrefs.Bind: target must be a non-nil pointer to struct, got %T", target)
+	}
+	if prefs == nil || reflect.ValueOf(prefs).IsNil() {
+		return fmt.Errorf("XPrefs.Bind: prefs can not be nil")
+	}
+	var errs []error
+	bindStruct(prefs, rv.Elem(), "", &errs)
+	return errors.Join(errs...)
+}
+
+// BindWatch 将配置绑定至新的结构体实例，并在配置项变更后重新绑定。
+// prefs 为配置源，fn 为绑定完成后的回调函数，cfg 为绑定的结构体实例，err 为绑定过程中发生的错误。
+// 注册时立即绑定一次，此后每次重新读取配置文件且存在变更时绑定一次，每次绑定均使用新的实例，读取方可以安全地替换持有的配置。
+// 返回取消订阅的函数。
+func BindWatch[T any](prefs IBase, fn func(cfg *T, err error)) func() {
+	if fn == nil {
+		fmt.Printf("XPrefs.BindWatch: nil function.\n")
+		return func() {}
+	}
+	bind := func() {
+		cfg := new(T)
+		fn(cfg, Bind(prefs, cfg))
+	}
+	bind()
+	return onReload(bind)
+}
+
+// bindStruct 绑定结构体的所有字段，path 为结构体的字段路径，用于错误信息。
+func bindStruct(prefs IBase, rv reflect.Value, path string, errs *[]error) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		tag, hasTag := field.Tag.Lookup("prefs")
+		if tag == "-" {
+			continue
+		}
+		fv := rv.Field(i)
+
+		// 未声明标签的匿名结构体展开至当前层级
+		if field.Anonymous && !hasTag && field.Type.Kind() == reflect.Struct {
+			bindStruct(prefs, fv, path, errs)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		fpath := field.Name
+		if path != "" {
+			fpath = path + "." + field.Name
+		}
+
+		key, opts, _ := strings.Cut(tag, ",")
+		if key == "" {
+			key = field.Name
+		}
+
+		if prefs.Has(key) {
+			assign(fv, prefs.Get(key), fpath, key, errs)
+			continue
+		}
+		if def, ok := field.Tag.Lookup("default"); ok {
+			var derrs []error
+			assignDefault(fv, def, fpath, key, &derrs)
+			for _, err := range derrs {
+				*errs = append(*errs, fmt.Errorf("%w (default %q)", err, def))
+			}
+			continue
+		}
+		if opts != "optional" {
+			*errs = append(*errs, fmt.Errorf("XPrefs.Bind: field %v (key %v): missing", fpath, key))
+		}
+	}
+}
+
+// assignDefault 将默认值赋值给字段，数组的默认值使用逗号分隔。
+func assignDefault(fv reflect.Value, def string, path string, key string, errs *[]error) {
+	if fv.Kind() == reflect.Slice && !implements(fv) {
+		var items []any
+		if def != "" {
+			for _, item := range strings.Split(def, ",") {
+				items = append(items, strings.TrimSpace(item))
+			}
+		}
+		assign(fv, items, path, key, errs)
+		return
+	}
+	assign(fv, def, path, key, errs)
+}
+
+// implements 检查字段是否实现了 Decoder 或 encoding.TextUnmarshaler 接口。
+func implements(fv reflect.Value) bool {
+	pt := reflect.PointerTo(fv.Type())
+	return pt.Implements(decoderType) || pt.Implements(textUnmarshalerType)
+}
+
+// assign 将配置项的原始值赋值给字段，path 和 key 为字段路径及配置项的键名，发生的错误追加至 errs 中。
+// 结构体、数组及映射的元素逐一赋值，每个无效的元素均会记录错误。
+func assign(fv reflect.Value, raw any, path string, key string, errs *[]error) {
+	fail := func(err error) {
+		*errs = append(*errs, fmt.Errorf("XPrefs.Bind: field %v (key %v): %w", path, key, err))
+	}
+
+	switch fv.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(fv.Type().Elem())
+		count := len(*errs)
+		assign(elem.Elem(), raw, path, key, errs)
+		if len(*errs) == count {
+			fv.Set(elem)
+		}
+		return
+
+	case reflect.Struct:
+		if !implements(fv) {
+			prefs := toBase(raw)
+			if prefs == nil {
+				fail(mismatch(raw, fv.Type()))
+				return
+			}
+			bindStruct(prefs, fv, path, errs)
+			return
+		}
+
+	case reflect.Slice:
+		if !implements(fv) {
+			if raw == nil {
+				fv.Set(reflect.Zero(fv.Type()))
+				return
+			}
+			rv := reflect.ValueOf(raw)
+			if rv.Kind() != reflect.Slice {
+				fail(mismatch(raw, fv.Type()))
+				return
+			}
+			slice := reflect.MakeSlice(fv.Type(), rv.Len(), rv.Len())
+			for i := 0; i < rv.Len(); i++ {
+				assign(slice.Index(i), rv.Index(i).Interface(), fmt.Sprintf("%v[%v]", path, i), key, errs)
+			}
+			fv.Set(slice)
+			return
+		}
+
+	case reflect.Map:
+		if !implements(fv) {
+			if fv.Type().Key().Kind() != reflect.String {
+				fail(fmt.Errorf("unsupported map key type of %v", fv.Type().Key()))
+				return
+			}
+			prefs := toBase(raw)
+			if prefs == nil {
+				fail(mismatch(raw, fv.Type()))
+				return
+			}
+			m := reflect.MakeMapWithSize(fv.Type(), len(prefs.Keys()))
+			for _, k := range prefs.Keys() {
+				elem := reflect.New(fv.Type().Elem()).Elem()
+				assign(elem, prefs.Get(k), path+"."+k, key, errs)
+				m.SetMapIndex(reflect.ValueOf(k).Convert(fv.Type().Key()), elem)
+			}
+			fv.Set(m)
+			return
+		}
+	}
+
+	value, secret, err := reveal(raw)
+	if err != nil {
+		fail(err)
+		return
+	}
+	if err := convert(fv, value); err != nil {
+		if secret {
+			err = fmt.Errorf("can not convert decrypted value to %v", fv.Type()) // 避免明文出现在错误信息中
+		}
+		fail(err)
+	}
+}
+
+// convert 将原始值转换为基础类型、time.Duration 或实现了 Decoder、encoding.TextUnmarshaler 的字段。
+// 基础类型的转换规则与 Get 系列函数一致，参见 coerceBool、coerceInt、coerceFloat 及 coerceString。
+func convert(fv reflect.Value, raw any) error {
+	if fv.CanAddr() {
+		addr := fv.Addr()
+		if addr.Type().Implements(decoderType) {
+			return addr.Interface().(Decoder).DecodePrefs(raw)
+		}
+		if addr.Type().Implements(textUnmarshalerType) {
+			if s, ok := raw.(string); ok {
+				return addr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+			}
+		}
+	}
+
+	if fv.Type() == durationType {
+		if s, ok := raw.(string); ok {
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return err
+			}
+			fv.SetInt(int64(d))
+			return nil
+		}
+		ms, ok := toFloat(raw)
+		if !ok {
+			return mismatch(raw, fv.Type())
+		}
+		fv.SetInt(int64(ms * float64(time.Millisecond)))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.Interface:
+		if raw == nil {
+			return nil
+		}
+		rv := reflect.ValueOf(raw)
+		if !rv.Type().AssignableTo(fv.Type()) {
+			return mismatch(raw, fv.Type())
+		}
+		fv.Set(rv)
+		return nil
+
+	case reflect.Bool:
+		b, err := coerceBool(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := coerceInt(raw)
+		if err != nil {
+			return err
+		}
+		if fv.OverflowInt(int64(i)) {
+			return fmt.Errorf("value %v overflows %v", raw, fv.Type())
+		}
+		fv.SetInt(int64(i))
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := coerceInt(raw)
+		if err != nil {
+			return err
+		}
+		if i < 0 || fv.OverflowUint(uint64(i)) {
+			return fmt.Errorf("value %v overflows %v", raw, fv.Type())
+		}
+		fv.SetUint(uint64(i))
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+		f, err := coerceFloat(raw)
+		if err != nil {
+			return err
+		}
+		if fv.OverflowFloat(f) {
+			return fmt.Errorf("value %v overflows %v", raw, fv.Type())
+		}
+		fv.SetFloat(f)
+		return nil
+
+	case reflect.String:
+		str, err := coerceString(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetString(str)
+		return nil
+	}
+
+	return fmt.Errorf("unsupported field type of %v", fv.Type())
+}
+
+// toBase 将原始值转换为配置实例，支持 IBase 及 map[string]any，其他类型返回 nil。
+func toBase(raw any) IBase {
+	switch v := raw.(type) {
+	case IBase:
+		if reflect.ValueOf(v).IsNil() {
+			return nil
+		}
+		return v
+	case map[string]any:
+		return &prefsBase{pairs: v}
+	}
+	return nil
+}
+
+// toFloat 将数值类型的原始值转换为浮点数。
+func toFloat(raw any) (float64, bool) {
+	rv := reflect.ValueOf(raw)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// mismatch 返回原始值与字段类型不匹配的错误。
+func mismatch(raw any, rt reflect.Type) error {
+	return fmt.Errorf("can not assign %T to %v", raw, rt)
+}
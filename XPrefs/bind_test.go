@@ -0,0 +1,251 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XPrefs
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// level 用于测试自定义的配置解码。
+type level int
+
+func (l *level) DecodePrefs(value any) error {
+	switch value {
+	case "Debug":
+		*l = 1
+	case "Info":
+		*l = 2
+	default:
+		return fmt.Errorf("unknown level %v", value)
+	}
+	return nil
+}
+
+type bindServer struct {
+	Host string `prefs:"Host"`
+	Port int    `prefs:"Port" default:"8080"`
+}
+
+type bindCommon struct {
+	Name string `prefs:"Name"`
+}
+
+type bindConfig struct {
+	bindCommon
+	Count    int            `prefs:"Loom/Count" default:"1"`
+	Step     time.Duration  `prefs:"Loom/Step"`
+	Timeout  time.Duration  `prefs:"Timeout" default:"5s"`
+	Ratio    float32        `prefs:"Ratio"`
+	Enabled  bool           `prefs:"Enabled"`
+	Tags     []string       `prefs:"Tags" default:"a, b"`
+	Ports    []uint16       `prefs:"Ports,optional"`
+	Level    level          `prefs:"Level"`
+	IP       net.IP         `prefs:"IP,optional"`
+	UI       bindServer     `prefs:"UI"`
+	Backup   *bindServer    `prefs:"Backup,optional"`
+	Servers  []bindServer   `prefs:"Servers"`
+	Limits   map[string]int `prefs:"Limits,optional"`
+	Raw      IBase          `prefs:"UI"`
+	Extra    any            `prefs:"Extra,optional"`
+	Ignored  string         `prefs:"-"`
+	internal string
+}
+
+func TestBind(t *testing.T) {
+	data := `{
+		"Name": "App",
+		"Loom/Step": 10,
+		"Ratio": 0.5,
+		"Enabled": true,
+		"Ports": [80, 443],
+		"Level": "Info",
+		"IP": "127.0.0.1",
+		"UI": {"Host": "ui.local", "Port": 9000},
+		"Servers": [{"Host": "a.local"}, {"Host": "b.local", "Port": 81}],
+		"Limits": {"cpu": 2, "mem": 4},
+		"Ignored": "value"
+	}`
+
+	t.Run("Basic", func(t *testing.T) {
+		pb := &prefsBase{}
+		assert.True(t, pb.parse([]byte(data)))
+
+		var cfg bindConfig
+		assert.NoError(t, Bind(pb, &cfg))
+		assert.Equal(t, "App", cfg.Name, "匿名结构体应当展开至当前层级")
+		assert.Equal(t, 1, cfg.Count, "配置项不存在时应当使用默认值")
+		assert.Equal(t, time.Millisecond*10, cfg.Step, "数值应当视为毫秒")
+		assert.Equal(t, time.Second*5, cfg.Timeout, "字符串应当解析为时长")
+		assert.Equal(t, float32(0.5), cfg.Ratio)
+		assert.True(t, cfg.Enabled)
+		assert.Equal(t, []string{"a", "b"}, cfg.Tags, "数组的默认值应当使用逗号分隔")
+		assert.Equal(t, []uint16{80, 443}, cfg.Ports)
+		assert.Equal(t, level(2), cfg.Level, "应当使用自定义的解码")
+		assert.Equal(t, "127.0.0.1", cfg.IP.String(), "应当支持 encoding.TextUnmarshaler")
+		assert.Equal(t, bindServer{Host: "ui.local", Port: 9000}, cfg.UI, "多级配置应当绑定至结构体")
+		assert.Nil(t, cfg.Backup, "可选的配置项不存在时应当保持零值")
+		assert.Equal(t, []bindServer{{Host: "a.local", Port: 8080}, {Host: "b.local", Port: 81}}, cfg.Servers,
+			"多级配置的数组应当绑定至结构体数组")
+		assert.Equal(t, map[string]int{"cpu": 2, "mem": 4}, cfg.Limits)
+		assert.Equal(t, "ui.local", cfg.Raw.GetString("Host"), "应当支持绑定至配置实例")
+		assert.Nil(t, cfg.Extra)
+		assert.Empty(t, cfg.Ignored, "忽略的字段不应当被绑定")
+	})
+
+	t.Run("Gets", func(t *testing.T) {
+		pb := &prefsBase{}
+		pb.Set("Servers", []map[string]any{{"Host": "a.local"}, {"Host": "b.local", "Port": 81}})
+		pb.Gets("Servers")
+
+		var cfg struct {
+			Servers []*bindServer `prefs:"Servers"`
+		}
+		assert.NoError(t, Bind(pb, &cfg))
+		assert.Equal(t, []*bindServer{{Host: "a.local", Port: 8080}, {Host: "b.local", Port: 81}}, cfg.Servers)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		pb := &prefsBase{}
+		assert.True(t, pb.parse([]byte(`{
+			"Loom/Count": "many",
+			"Loom/Step": "fast",
+			"Ratio": "high",
+			"Enabled": "maybe",
+			"Ports": [80, -1],
+			"Level": "Verbose",
+			"UI": "ui.local",
+			"Servers": [{"Port": "x"}]
+		}`)))
+
+		var cfg bindConfig
+		err := Bind(pb, &cfg)
+		assert.Error(t, err)
+		for _, field := range []string{
+			"field Name (key Name): missing",
+			"field Count (key Loom/Count)",
+			"field Step (key Loom/Step)",
+			"field Ratio (key Ratio)",
+			"field Enabled (key Enabled)",
+			"field Ports[1] (key Ports)",
+			"field Level (key Level)",
+			"field UI (key UI)",
+			"field Servers[0].Host (key Host): missing",
+			"field Servers[0].Port (key Port)",
+		} {
+			assert.Contains(t, err.Error(), field, "应当报告所有缺失或无效的字段")
+		}
+		assert.Len(t, strings.Split(err.Error(), "\n"), 11)
+
+		var invalid struct {
+			Count int `prefs:"Count" default:"x"`
+		}
+		assert.ErrorContains(t, Bind(New(), &invalid), `default "x"`, "无效的默认值应当报告错误")
+		assert.Error(t, Bind(New(), cfg), "非指针的目标应当报告错误")
+		assert.Error(t, Bind(nil, &cfg), "空的配置源应当报告错误")
+	})
+
+	t.Run("Coerce", func(t *testing.T) {
+		type scalars struct {
+			Bool   bool    `prefs:"Bool"`
+			Int    int     `prefs:"Int"`
+			Int8   int8    `prefs:"Int8,optional"`
+			Uint   uint    `prefs:"Uint,optional"`
+			Float  float64 `prefs:"Float"`
+			String string  `prefs:"String"`
+		}
+		bind := func(key string, value any) (scalars, error) {
+			pb := New().Set("Bool", false).Set("Int", 0).Set("Float", 0).Set("String", "").Set(key, value)
+			var cfg scalars
+			return cfg, Bind(pb, &cfg)
+		}
+
+		for _, v := range []any{"true", "1", "YES", "y", "On", true, 1, float64(1)} {
+			cfg, err := bind("Bool", v)
+			assert.NoError(t, err, "%v 应当与 GetBool 一致绑定为 true", v)
+			assert.Equal(t, New().Set("Bool", v).GetBool("Bool"), cfg.Bool)
+			assert.True(t, cfg.Bool)
+		}
+		for _, v := range []any{"maybe", 2} {
+			_, err := bind("Bool", v)
+			assert.Error(t, err, "%v 不应当绑定为布尔值", v)
+		}
+
+		for _, v := range []any{" 42 ", uint8(7), "3.9", 1.5, "1.5"} {
+			cfg, err := bind("Int", v)
+			assert.NoError(t, err, "%v 应当与 GetInt 一致绑定为整数", v)
+			assert.Equal(t, New().Set("Int", v).GetInt("Int"), cfg.Int)
+		}
+		_, err := bind("Int", "abc")
+		assert.Error(t, err)
+		_, err = bind("Int8", 300)
+		assert.ErrorContains(t, err, "overflows", "超出字段范围的整数应当报告错误")
+		_, err = bind("Uint", -1)
+		assert.ErrorContains(t, err, "overflows", "负数不应当绑定至无符号整数")
+
+		for _, v := range []any{"1.5", int64(2)} {
+			cfg, err := bind("Float", v)
+			assert.NoError(t, err, "%v 应当与 GetFloat 一致绑定为浮点数", v)
+			assert.Equal(t, float64(New().Set("Float", v).GetFloat("Float")), cfg.Float)
+		}
+		_, err = bind("Float", "1.5x")
+		assert.Error(t, err)
+
+		for _, v := range []any{"a", 3.14, float32(0.5), int64(-3), uint(7), true} {
+			cfg, err := bind("String", v)
+			assert.NoError(t, err, "%v 应当与 GetString 一致绑定为字符串", v)
+			assert.Equal(t, New().Set("String", v).GetString("String"), cfg.String)
+		}
+		_, err = bind("String", map[string]any{})
+		assert.Error(t, err, "对象不应当绑定为字符串")
+	})
+
+	t.Run("Watch", func(t *testing.T) {
+		originalArgs := os.Args
+		defer func() {
+			os.Args = originalArgs
+			reset()
+		}()
+
+		assetFile := filepath.Join(t.TempDir(), "Preferences.json")
+		assert.NoError(t, writeFile(assetFile, []byte(`{"Prefs/Watch/Interval": 10, "Host": "a.local"}`)))
+		reset()
+		os.Args = []string{"test", "--Prefs@Asset=" + assetFile}
+
+		var mu sync.Mutex
+		var binds []*bindServer
+		unsub := BindWatch(Asset(), func(cfg *bindServer, err error) {
+			assert.NoError(t, err)
+			mu.Lock()
+			defer mu.Unlock()
+			binds = append(binds, cfg)
+		})
+		defer unsub()
+		count := func() int {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(binds)
+		}
+		assert.Equal(t, 1, count(), "注册时应当立即绑定")
+
+		assert.NoError(t, writeFile(assetFile, []byte(`{"Prefs/Watch/Interval": 10, "Host": "b.local", "Port": 81}`)))
+		assert.Eventually(t, func() bool { return count() == 2 }, time.Second, time.Millisecond*10,
+			"配置变更后应当重新绑定，且每次重新读取仅绑定一次")
+		time.Sleep(time.Millisecond * 50)
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Len(t, binds, 2)
+		assert.Equal(t, &bindServer{Host: "a.local", Port: 8080}, binds[0], "原有的实例不应当被修改")
+		assert.Equal(t, &bindServer{Host: "b.local", Port: 81}, binds[1])
+	})
+}
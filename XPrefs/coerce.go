@@ -0,0 +1,158 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XPrefs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrNotFound 表示配置项不存在，Try* 系列函数在配置项不存在时返回包装了该错误的错误，可通过 errors.Is 判断。
+var ErrNotFound = errors.New("key was not found")
+
+// boolStrings 可转换为布尔值的字符串，不区分大小写。
+var boolStrings = map[string]bool{
+	"true": true, "1": true, "yes": true, "y": true, "on": true,
+	"false": false, "0": false, "no": false, "n": false, "off": false,
+}
+
+// notFound 返回配置项不存在的错误。
+func notFound(key string) error {
+	return fmt.Errorf("XPrefs: %w: %v", ErrNotFound, key)
+}
+
+// malformed 返回配置项的值无法转换的错误。
+func malformed(key string, err error) error {
+	return fmt.Errorf("XPrefs: key %v: %w", key, err)
+}
+
+// cannot 返回值无法转换为指定类型的错误。
+func cannot(value any, typ string) error {
+	return fmt.Errorf("can not convert %T(%v) to %v", value, value, typ)
+}
+
+// coerceInt 将值转换为整数。
+// 支持整数、浮点数（截断小数部分）、可解析为数值的字符串，其他类型返回错误。
+func coerceInt(value any) (int, error) {
+	if s, ok := value.(string); ok {
+		s = strings.TrimSpace(s)
+		if i, err := strconv.Atoi(s); err == nil {
+			return i, nil
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return 0, cannot(value, "int")
+		}
+		return int(f), nil
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		if f := rv.Float(); !math.IsNaN(f) && !math.IsInf(f, 0) {
+			return int(f), nil
+		}
+	}
+	return 0, cannot(value, "int")
+}
+
+// coerceFloat 将值转换为浮点数。
+// 支持整数、浮点数及可解析为数值的字符串，其他类型返回错误。
+func coerceFloat(value any) (float64, error) {
+	if s, ok := value.(string); ok {
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return 0, cannot(value, "float")
+		}
+		return f, nil
+	}
+	if f, ok := toFloat(value); ok {
+		return f, nil
+	}
+	return 0, cannot(value, "float")
+}
+
+// coerceBool 将值转换为布尔值。
+// 支持布尔值、数值 0 和 1 及不区分大小写的 true/false、1/0、yes/no、y/n、on/off 字符串，其他类型返回错误。
+func coerceBool(value any) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		if b, ok := boolStrings[strings.ToLower(strings.TrimSpace(v))]; ok {
+			return b, nil
+		}
+		return false, cannot(value, "bool")
+	}
+	if f, ok := toFloat(value); ok && (f == 0 || f == 1) {
+		return f == 1, nil
+	}
+	return false, cannot(value, "bool")
+}
+
+// coerceString 将值转换为字符串。
+// 支持字符串、数值及布尔值，数值按照最短的十进制格式输出，其他类型返回错误。
+func coerceString(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	}
+	return "", cannot(value, "string")
+}
+
+// coerceSlice 将值转换为指定类型的数组，elem 为元素的转换函数。
+// 支持任意类型的数组及 JSON 数组格式的字符串（如 "[1, 2]"），任一元素转换失败时返回包含索引的错误。
+func coerceSlice[T any](value any, elem func(any) (T, error)) ([]T, error) {
+	if v, ok := value.([]T); ok {
+		return v, nil
+	}
+	if s, ok := value.(string); ok {
+		var items []any
+		if err := json.Unmarshal([]byte(strings.TrimSpace(s)), &items); err != nil {
+			return nil, fmt.Errorf("can not convert %q to array: %v", s, err)
+		}
+		value = items
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice {
+		return nil, cannot(value, "array")
+	}
+	result := make([]T, rv.Len())
+	for i := range result {
+		item, err := elem(rv.Index(i).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("index %v: %w", i, err)
+		}
+		result[i] = item
+	}
+	return result, nil
+}
+
+// coerceFloat32 将值转换为 32 位浮点数。
+func coerceFloat32(value any) (float32, error) {
+	f, err := coerceFloat(value)
+	return float32(f), err
+}
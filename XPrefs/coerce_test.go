@@ -0,0 +1,157 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XPrefs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoerce(t *testing.T) {
+	t.Run("Scalar", func(t *testing.T) {
+		for _, v := range []any{"true", "1", "YES", "y", "On", true, 1, float64(1)} {
+			b, err := coerceBool(v)
+			assert.NoError(t, err, "%v 应当转换为 true", v)
+			assert.True(t, b)
+		}
+		for _, v := range []any{"false", "0", "no", "N", "off", false, 0} {
+			b, err := coerceBool(v)
+			assert.NoError(t, err, "%v 应当转换为 false", v)
+			assert.False(t, b)
+		}
+		for _, v := range []any{"maybe", "", 2, nil, []any{}} {
+			_, err := coerceBool(v)
+			assert.Error(t, err, "%v 不应当转换为布尔值", v)
+		}
+
+		i, err := coerceInt(" 42 ")
+		assert.NoError(t, err)
+		assert.Equal(t, 42, i)
+		i, _ = coerceInt(uint8(7))
+		assert.Equal(t, 7, i)
+		i, _ = coerceInt("3.9")
+		assert.Equal(t, 3, i, "字符串形式的浮点数应当与浮点数一致截断小数部分")
+		_, err = coerceInt("abc")
+		assert.Error(t, err)
+		_, err = coerceInt(true)
+		assert.Error(t, err)
+
+		f, err := coerceFloat("1.5")
+		assert.NoError(t, err)
+		assert.Equal(t, 1.5, f)
+		f, _ = coerceFloat(int64(2))
+		assert.Equal(t, float64(2), f)
+		_, err = coerceFloat("1.5x")
+		assert.Error(t, err)
+
+		for expected, v := range map[string]any{"a": "a", "3.14": 3.14, "0.5": float32(0.5), "-3": int64(-3), "7": uint(7), "true": true} {
+			s, err := coerceString(v)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, s)
+		}
+		_, err = coerceString(map[string]any{})
+		assert.Error(t, err, "对象不应当转换为字符串")
+	})
+
+	t.Run("Slice", func(t *testing.T) {
+		ints, err := coerceSlice([]any{float64(1), "2", int8(3)}, coerceInt)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, ints)
+
+		ints, err = coerceSlice("[1, 2, 3]", coerceInt)
+		assert.NoError(t, err, "JSON 数组格式的字符串应当转换为数组")
+		assert.Equal(t, []int{1, 2, 3}, ints)
+
+		floats, err := coerceSlice([]int{1, 2}, coerceFloat32)
+		assert.NoError(t, err, "应当支持任意类型的数组")
+		assert.Equal(t, []float32{1, 2}, floats)
+
+		_, err = coerceSlice([]any{1, "x"}, coerceInt)
+		assert.ErrorContains(t, err, "index 1", "无效的元素应当报告索引")
+		_, err = coerceSlice("1, 2", coerceInt)
+		assert.Error(t, err)
+		_, err = coerceSlice(1, coerceInt)
+		assert.Error(t, err)
+	})
+
+	t.Run("Getters", func(t *testing.T) {
+		pb := New().
+			Set("bool", "yes").
+			Set("int", "42").
+			Set("float", "1.5").
+			Set("string", 3.14).
+			Set("ints", "[1, 2]").
+			Set("floats", []any{1, "2.5"}).
+			Set("bools", `["true", 0]`).
+			Set("strings", []any{"a", 1, true}).
+			Set("invalid", "abc").
+			Set("invalids", []any{1, "x"})
+
+		assert.True(t, pb.GetBool("bool"), "字符串 yes 应当转换为 true")
+		assert.Equal(t, 42, pb.GetInt("int"))
+		assert.Equal(t, float32(1.5), pb.GetFloat("float"))
+		assert.Equal(t, "3.14", pb.GetString("string"))
+		assert.Equal(t, []int{1, 2}, pb.GetInts("ints"))
+		assert.Equal(t, []float32{1, 2.5}, pb.GetFloats("floats"), "非 float64 的元素应当被转换")
+		assert.Equal(t, []bool{true, false}, pb.GetBools("bools"))
+		assert.Equal(t, []string{"a", "1", "true"}, pb.GetStrings("strings"))
+		assert.Equal(t, 9, pb.GetInt("invalid", 9), "转换失败时应当返回默认值")
+		assert.Equal(t, []int{9}, pb.GetInts("invalids", []int{9}), "任一元素转换失败时应当返回默认值，而不是零值")
+		assert.Nil(t, pb.GetInts("invalids"))
+	})
+
+	t.Run("Try", func(t *testing.T) {
+		pb := New().Set("count", "4").Set("invalid", "abc")
+
+		count, err := pb.TryInt("count")
+		assert.NoError(t, err)
+		assert.Equal(t, 4, count)
+
+		_, err = pb.TryInt("missing")
+		assert.ErrorIs(t, err, ErrNotFound, "配置项不存在时应当返回 ErrNotFound")
+
+		_, err = pb.TryInt("invalid")
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, ErrNotFound), "转换失败时不应当返回 ErrNotFound")
+		assert.ErrorContains(t, err, "invalid")
+
+		_, err = pb.TryBools("invalid")
+		assert.Error(t, err)
+	})
+
+	t.Run("Sources", func(t *testing.T) {
+		originalArgs := os.Args
+		defer func() {
+			os.Args = originalArgs
+			reset()
+		}()
+		assetFile := filepath.Join(t.TempDir(), "Preferences.json")
+		assert.NoError(t, writeFile(assetFile, []byte(`{}`)))
+		os.Args = []string{"test", "--Prefs@Asset=" + assetFile, "--Prefs.Debug=true", "--Prefs.Ratio=0.5"}
+		reset()
+
+		assert.True(t, GetBool("Debug"), "命令行参数覆盖的字符串应当转换为布尔值")
+		assert.Equal(t, float32(0.5), GetFloat("Ratio", float32(0)))
+
+		local := New().Set("Count", "x")
+		Asset().Set("Count", 3)
+		assert.Equal(t, 5, GetInt("Count", 5, local), "配置源中的值转换失败时应当返回默认值")
+
+		_, err := TryInt("Count", local)
+		assert.Error(t, err, "首个包含配置项的配置源应当决定返回的结果")
+		assert.False(t, errors.Is(err, ErrNotFound))
+
+		count, err := TryInt("Count", New())
+		assert.NoError(t, err, "配置源均不包含时应当在资产配置中查找")
+		assert.Equal(t, 3, count)
+
+		_, err = TryString("Missing", local)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
@@ -8,8 +8,15 @@ XPrefs 是一个灵活高效的配置系统，实现了多源化配置的读写
 功能特性
 
   - 多源化配置：支持内置配置（只读）、本地配置（可写）和远程配置（只读），支持多个配置源按优先级顺序读取
+  - 热重载：支持配置文件变更后自动重新读取，通过 OnChange 订阅配置项的变更
   - 多数据类型：支持基础类型（整数、浮点数、布尔值、字符串）、数组类型及配置实例（IBase）
+  - 多文件格式：支持 JSON、YAML 和 TOML 格式的配置文件，根据文件扩展名自动识别
+  - 结构体绑定：支持通过结构体标签将配置绑定至结构体，并在配置变更后重新绑定
+  - 配置校验：支持按键名前缀注册配置项的校验规则，报告未知的配置项、类型错误及超出范围的值
   - 变量求值：支持通过命令行参数动态覆盖配置项，使用 ${Prefs.Key} 语法引用其他配置项
+  - 环境变量覆盖：支持通过 XPREFS__Loom__Count=4 形式的环境变量覆盖配置项，值按照 JSON 字面量解析
+  - 叠加配置：支持按照运行模式及渠道深度合并 Preferences.<Mode>.json 等叠加配置，通过 Explain 查看配置项的来源
+  - 加密配置：支持 ENC(...) 格式的 AES-GCM 加密配置项，读取时透明地解密且明文不会出现在 Json 输出及日志中
 
 使用手册
 
@@ -45,8 +52,8 @@ XPrefs 是一个灵活高效的配置系统，实现了多源化配置的读写
 	// 从指定的配置源中查找
 	value := XPrefs.Get("key", "default", local, asset)  // 优先从 local 查找，然后是 asset
 
-	// 从默认配置源中查找（仅 asset）
-	value := XPrefs.Get("key", "default")  // 等同于 asset.Get("key")
+	// 从默认配置源中查找（已加载的 remote 及 asset）
+	value := XPrefs.Get("key", "default")  // 未加载远程配置时等同于 asset.Get("key")
 
 	// 检查配置项是否存在于任意源
 	exists := XPrefs.HasKey("key", local, asset)  // 依次检查 local 和 asset
@@ -85,6 +92,19 @@ XPrefs 是一个灵活高效的配置系统，实现了多源化配置的读写
 	// 获取字符串值
 	strVal := XPrefs.GetString("name", "")
 
+	// 区分缺失与无效的配置项
+	if count, err := XPrefs.TryInt("count"); errors.Is(err, XPrefs.ErrNotFound) {
+		// 配置项不存在
+	} else if err != nil {
+		// 配置项无法转换为整数
+	}
+
+所有的 Get 系列函数使用一致的类型转换规则，转换失败时返回默认值，Try 系列函数则返回错误：
+  - 整数：支持整数、浮点数（截断小数部分）及可解析为数值的字符串，如 "42"
+  - 浮点数：支持整数、浮点数及可解析为数值的字符串，如 "1.5"
+  - 布尔值：支持布尔值、数值 0 和 1 及不区分大小写的 true/false、1/0、yes/no、y/n、on/off
+  - 字符串：支持字符串、数值及布尔值，如 3.14 转换为 "3.14"
+
 2.2 数组类型
 
 	// 获取整数数组
@@ -99,6 +119,38 @@ XPrefs 是一个灵活高效的配置系统，实现了多源化配置的读写
 	// 获取字符串数组
 	strArray := XPrefs.GetStrings("names")
 
+数组支持任意类型的数组及 JSON 数组格式的字符串（如命令行参数 --Prefs.Ports=[80,443]），元素的转换规则与基础类型一致，任一元素转换失败时返回默认值。
+
+2.3 结构体绑定
+
+	type Server struct {
+		Host string `prefs:"Host"`
+		Port int    `prefs:"Port" default:"8080"`
+	}
+
+	type Config struct {
+		Count   int           `prefs:"Loom/Count" default:"1"` // 配置项不存在时使用默认值
+		Step    time.Duration `prefs:"Loom/Step"`              // 字符串如 "5s"，数值视为毫秒
+		Tags    []string      `prefs:"Tags" default:"a,b"`     // 数组的默认值使用逗号分隔
+		UI      Server        `prefs:"UI"`                     // 多级配置
+		Servers []Server      `prefs:"Servers,optional"`       // 多级配置的数组，可以不存在
+		Ignored string        `prefs:"-"`                      // 忽略该字段
+	}
+
+	// 绑定配置，返回所有缺失或无效的字段合并后的错误
+	var cfg Config
+	err := XPrefs.Bind(XPrefs.Asset(), &cfg)
+
+	// 配置文件重新读取后自动重新绑定，每次绑定均使用新的实例
+	unsub := XPrefs.BindWatch(XPrefs.Asset(), func(cfg *Config, err error) {
+		if err == nil {
+			current.Store(cfg)
+		}
+	})
+
+字段的指针实现 Decoder 或 encoding.TextUnmarshaler 时由其自行解码；未指定默认值且非可选的字段缺失时报告错误。
+基础类型的转换规则与 Get 系列函数一致，如 "yes" 绑定至布尔值为 true，1.5 绑定至整数为 1。
+
 3. 多级配置
 
 3.1 设置多级配置
@@ -118,7 +170,23 @@ XPrefs 是一个灵活高效的配置系统，实现了多源化配置的读写
 		Get("Style").(XPrefs.IBase).
 		GetString("Theme")  // 返回 "Dark"
 
-3.2 命令行参数设置
+3.2 路径访问
+
+	// 使用 . 分隔各级配置，键名中的 / 无需转义
+	local.SetPath("UI.Window.Style.Theme", "Dark")   // 不存在的上级配置将被创建
+	theme := local.GetPath("UI.Window.Style.Theme")   // 返回 "Dark"
+	level := XPrefs.GetPathAs[string](XPrefs.Asset(), "Log/File.Level", "Info")
+	host := XPrefs.GetPath("Servers.0.Host", "")      // 数组的元素使用数字索引
+
+	// 键名中的 . 和 \ 需分别转义为 \. 和 \\，可使用 EscapeKey 转义
+	port := local.GetPath("Hosts." + XPrefs.EscapeKey("example.com") + ".Port")
+
+	// 删除多级配置项，上级配置不会被删除
+	local.UnsetPath("UI.Window.Style.Theme")
+
+路径访问与 Get、Set 共享多级配置的缓存，通过任一方式修改后均可读取到最新的值；命令行参数、环境变量及变量引用使用相同的路径语法。
+
+3.3 命令行参数设置
 
 命令行参数支持以下几种用法：
   - 指定配置文件路径：--Prefs@Asset=path/to/asset.json
@@ -126,6 +194,51 @@ XPrefs 是一个灵活高效的配置系统，实现了多源化配置的读写
   - 设置多级配置项：--Prefs@Local.UI.Theme=Dark
   - 设置日志级别：--Prefs.Log.Level=Debug
 
+3.4 配置文件格式
+
+根据文件扩展名识别配置文件的格式：JSON（.json，默认）、YAML（.yaml、.yml）和 TOML（.toml）。
+YAML 和 TOML 中的多级配置与 JSON 对象一致，均可通过 Get 获取为配置实例：
+
+	// 使用 YAML 或 TOML 格式的配置文件
+	./program --Prefs@Asset=path/to/asset.yaml --Prefs@Local=path/to/local.toml
+
+默认的 Preferences.json 不存在时，会依次查找同目录下的 Preferences.yaml、Preferences.yml 和 Preferences.toml；本地配置保存时沿用读取时的格式。
+
+3.5 环境变量设置
+
+	// 作用于所有配置源，键名中的 __ 转换为 /，即 Loom/Count
+	XPREFS__Loom__Count=4 ./program
+
+	// 仅作用于指定的配置源（ASSET、LOCAL 或 REMOTE），. 表示多级配置
+	XPREFS_LOCAL__Log__File.Level=Debug ./program
+
+	// 值按照 JSON 字面量解析，支持数值、布尔值、数组及对象
+	// 数值仅在可无损还原时转换，如 1.10 及超出 int64 范围的整数保留为字符串
+	XPREFS__Debug=true XPREFS__Tags='["a", "b"]' ./program
+
+	// 自定义前缀和分隔符，前缀为空时不读取环境变量
+	MYAPP_Loom_Count=4 ./program --Prefs@Env=MYAPP --Prefs@EnvSeparator=_
+
+覆盖的优先级为：指定配置源的命令行参数 > 指定配置源的环境变量 > 作用于所有配置源的命令行参数 > 作用于所有配置源的环境变量 > 配置文件。
+
+3.6 叠加配置
+
+资产配置读取后，会依次深度合并同目录下的叠加配置文件（如 Preferences.Prod.json、Preferences.Steam.json），
+叠加配置的名称由 SetProfile 注册的解析函数返回，未注册时不叠加。引入 XEnv 时将注册运行模式及渠道，
+与 XEnv.Mode()、XEnv.Channel() 一致，读取自基础配置中的 Env/Mode（默认为 Dev）和 Env/Channel（默认为 Default）。
+对象逐级合并，数组及其他类型的值直接替换，值为 null 时删除配置项：
+
+	// 查看配置项的来源，如 Assets/Preferences.Prod.json、env XPREFS__Key 或 arg --Prefs.Key
+	source := XPrefs.Explain("Log/Std.Level")
+
+	// 注册叠加配置的名称，get 读取基础配置中的配置项（应用覆盖后），需要在首次调用 Asset 之前注册
+	XPrefs.SetProfile(func(get func(key, defval string) string) []string {
+		return []string{get("Env/Mode", "Dev"), get("Env/Channel", "Default")}
+	})
+
+	// 指定叠加的配置名称，多个名称以逗号分隔，为空时不叠加，优先级高于注册的解析函数
+	./program --Prefs@Profile=Prod,Steam
+
 4. 变量引用
 
 4.1 基本引用
@@ -167,6 +280,87 @@ XPrefs 是一个灵活高效的配置系统，实现了多源化配置的读写
   - SIGTERM：终止信号
   - SIGINT：中断信号（Ctrl+C）
 
+5.3 热重载
+
+	// 订阅配置项的变更，keyPrefix 为空时订阅所有配置项
+	unsub := XPrefs.OnChange("Log/", func(old, new any) {
+		fmt.Printf("changed from %v to %v\n", old, new)
+	})
+
+	// 取消订阅
+	unsub()
+
+资产配置和本地配置的文件变更后会自动重新读取：
+  - 变更检测：轮询配置文件的修改时间和大小，间隔为 Prefs/Watch/Interval（毫秒，默认 1000，小于等于 0 时不检测）
+  - 原子替换：重新读取的配置项整体替换，读取方不会观察到部分替换的配置
+  - 错误保护：无效的配置文件（如 JSON 格式错误或未通过校验）将被拒绝，保留原有的配置
+  - 本地配置：通过 Save 写入的变更不会触发重新读取，外部修改文件时未保存的修改将被覆盖
+  - 覆盖配置：用于覆盖配置项的命令行参数及环境变量在初始化时读取，重新读取时沿用初始化时的值
+
+6. 远程配置
+
+6.1 拉取远程配置
+
+	// 获取远程配置（只读），首次调用时通过 HTTP(S) 拉取
+	remote := XPrefs.Remote()
+
+	// 按照 Local、Remote、Asset 的顺序查找配置项
+	value := XPrefs.GetString("key", "default", XPrefs.Local())
+
+远程配置的地址为 XEnv.Remote()，引用 XEnv 模块时自动注册，也可以通过命令行参数 --Prefs@Remote=<url> 指定：
+  - 条件请求：携带缓存的 ETag 和 Last-Modified，服务端返回 304 时使用本地缓存
+  - 超时重试：单次请求超时为 Prefs/Remote/Timeout（毫秒，默认 5000），失败后重试 Prefs/Remote/Retry 次（默认 2）
+  - 离线启动：拉取成功后缓存至 XEnv.LocalPath()/Preferences.Remote.json，请求失败时回退至缓存
+  - 查找顺序：加载后，包级的 Get*、Try* 函数在指定的配置源之后、资产配置之前查找远程配置；未调用 Remote 时不会发起请求
+
+6.2 自定义地址
+
+	// 注册远程配置地址和缓存目录的解析函数，需要在首次调用 Remote 之前注册
+	XPrefs.SetRemote(func() string { return "https://example.com/Preferences.json" }, func() string { return "Local" })
+
+7. 配置校验
+
+7.1 注册校验规则
+
+	// 注册 App/ 前缀下所有配置项的校验规则
+	XPrefs.RegisterSchema("App/", map[string]*XPrefs.Schema{
+		"App/Name":  {Type: XPrefs.TypeString},
+		"App/Count": {Type: XPrefs.TypeInteger, Range: []float64{1, 100}},
+		"App/Log": {Type: XPrefs.TypeObject, Fields: map[string]*XPrefs.Schema{
+			"Level": {Type: XPrefs.TypeString, Enum: []any{"Debug", "Info"}},
+		}},
+		"App/Ports": {Type: XPrefs.TypeArray, Items: &XPrefs.Schema{Type: XPrefs.TypeInteger}},
+	})
+
+	// 使用所有已注册的规则校验配置，返回合并后的错误
+	if err := XPrefs.Validate(XPrefs.Asset()); err != nil {
+		fmt.Println(err)
+	}
+
+7.2 校验规则
+
+校验规则为 JSON Schema 的子集：
+  - 类型：string、integer、number、boolean、array 及 object，整数和数值类型与 Get 系列函数的转换规则一致，如 "1.5" 可通过整数类型的校验
+  - 取值：Enum 限定可选值，Range 限定数值范围，Check 支持自定义的校验函数
+  - 嵌套：Fields 校验多级配置的字段，Items 校验数组的元素
+  - 未知配置：以注册的前缀开头但未声明的配置项（如拼写错误的 Log/Fiel）将被报告
+
+配置读取、热重载及注册规则时均会校验，错误输出至标准输出，热重载时校验失败的配置将被拒绝。XLog 和 XLoom 已分别注册了 Log/ 和 Loom/ 的校验规则，XPrefs 注册了 Prefs/ 的校验规则。
+
+8. 加密配置
+
+使用 AES-GCM 加密配置项的值，加密后的 ENC(...) 格式的字符串可直接写入配置文件：
+
+	// 生成 AES-256 密钥，妥善保存且不要提交至代码仓库
+	key, _ := XPrefs.GenerateKey()
+	value, _ := XPrefs.Encrypt("p@ssw0rd", key)   // ENC(q2Fv...)
+
+	// 依次读取 --Prefs@Key、--Prefs@KeyFile、XPREFS_KEY 及 XPREFS_KEY_FILE 提供的 Base64 编码的密钥
+	password := XPrefs.Asset().GetString("DB.Password")  // Get 系列函数、GetPathAs 及 Bind 透明地解密
+
+解密后的值不会写回配置，Json、Save 及变量引用均保留 ENC(...) 格式；类型转换失败的错误信息不包含明文，加密的配置项不参与校验。
+读取成功的密钥将被缓存，读取失败时下次解密重新读取，支持在启动后挂载的密钥文件。
+
 更多信息请参考模块文档。
 */
 package XPrefs
@@ -0,0 +1,130 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XPrefs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	formatJson = "json" // JSON 格式
+	formatYaml = "yaml" // YAML 格式
+	formatToml = "toml" // TOML 格式
+)
+
+// formatExts 默认配置文件不存在时依次查找的其他格式的扩展名。
+var formatExts = []string{".yaml", ".yml", ".toml"}
+
+// formatOf 根据文件扩展名获取配置文件的格式。
+// .yaml 和 .yml 为 YAML 格式，.toml 为 TOML 格式，其他均为 JSON 格式。
+func formatOf(file string) string {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		return formatYaml
+	case ".toml":
+		return formatToml
+	default:
+		return formatJson
+	}
+}
+
+// locate 查找默认的配置文件。
+// 如果 JSON 格式的文件不存在，则依次查找同名的 .yaml、.yml 和 .toml 文件，均不存在时返回原路径。
+func locate(file string) string {
+	if fileExists(file) {
+		return file
+	}
+	base := strings.TrimSuffix(file, filepath.Ext(file))
+	for _, ext := range formatExts {
+		if candidate := base + ext; fileExists(candidate) {
+			return candidate
+		}
+	}
+	return file
+}
+
+// decode 将配置文件的数据转换为 JSON 格式。
+// 根据文件扩展名识别 YAML 或 TOML 格式，转换后的多级配置与 JSON 对象一致；JSON 格式的数据原样返回。
+func decode(file string, data []byte) ([]byte, error) {
+	format := formatOf(file)
+	if format == formatJson || len(data) == 0 {
+		return data, nil
+	}
+
+	var kvs map[string]any
+	var err error
+	switch format {
+	case formatYaml:
+		err = yaml.Unmarshal(data, &kvs)
+	case formatToml:
+		err = toml.Unmarshal(data, &kvs)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal %v error: %v", format, err)
+	}
+	if kvs == nil {
+		kvs = map[string]any{}
+	}
+	return json.Marshal(kvs)
+}
+
+// encode 将 JSON 格式的配置数据转换为配置文件的格式。
+// 根据文件扩展名输出 YAML 或 TOML 格式，整数保持为整数；JSON 格式的数据原样返回。
+func encode(file string, data string) ([]byte, error) {
+	format := formatOf(file)
+	if format == formatJson {
+		return []byte(data), nil
+	}
+
+	var kvs map[string]any
+	dec := json.NewDecoder(strings.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&kvs); err != nil {
+		return nil, err
+	}
+	value := denumber(kvs)
+
+	switch format {
+	case formatYaml:
+		return yaml.Marshal(value)
+	default:
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Encode(value); err != nil {
+			return nil, fmt.Errorf("marshal %v error: %v", format, err)
+		}
+		return buf.Bytes(), nil
+	}
+}
+
+// denumber 将 json.Number 转换为整数或浮点数。
+func denumber(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for k, vv := range v {
+			v[k] = denumber(vv)
+		}
+		return v
+	case []any:
+		for i, vv := range v {
+			v[i] = denumber(vv)
+		}
+		return v
+	default:
+		return value
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XPrefs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	originalArgs := os.Args
+	defer func() {
+		os.Args = originalArgs
+		reset()
+	}()
+	os.Args = []string{"test"}
+
+	jsonData := `{"name": "App", "count": 3, "ratio": 0.5, "tags": ["a", "b"], "UI": {"Theme": "Dark", "Size": 12}}`
+	yamlData := `
+name: App
+count: 3
+ratio: 0.5
+tags: [a, b]
+UI:
+  Theme: Dark
+  Size: 12
+`
+	tomlData := `
+name = "App"
+count = 3
+ratio = 0.5
+tags = ["a", "b"]
+
+[UI]
+Theme = "Dark"
+Size = 12
+`
+
+	t.Run("Detect", func(t *testing.T) {
+		assert.Equal(t, formatYaml, formatOf("Preferences.yaml"))
+		assert.Equal(t, formatYaml, formatOf("Preferences.YML"))
+		assert.Equal(t, formatToml, formatOf("Preferences.toml"))
+		assert.Equal(t, formatJson, formatOf("Preferences.json"))
+		assert.Equal(t, formatJson, formatOf("Preferences"))
+	})
+
+	t.Run("Decode", func(t *testing.T) {
+		expected := &prefsBase{}
+		assert.True(t, expected.parse([]byte(jsonData)))
+
+		for file, data := range map[string]string{"Preferences.yaml": yamlData, "Preferences.yml": yamlData, "Preferences.toml": tomlData} {
+			jdata, err := decode(file, []byte(data))
+			assert.NoError(t, err)
+			pb := &prefsBase{}
+			assert.True(t, pb.parse(jdata))
+			assert.Equal(t, expected.pairs, pb.pairs, "%v 格式的配置应当与 JSON 格式一致", file)
+
+			ui, ok := pb.Get("UI").(IBase)
+			assert.True(t, ok, "%v 格式的多级配置应当转换为配置实例", file)
+			assert.Equal(t, "Dark", ui.GetString("Theme"))
+			assert.Equal(t, 12, ui.GetInt("Size"))
+			assert.Equal(t, float32(0.5), pb.GetFloat("ratio"))
+			assert.Equal(t, []string{"a", "b"}, pb.GetStrings("tags"))
+		}
+
+		_, err := decode("Preferences.yaml", []byte("name: [a"))
+		assert.Error(t, err, "无效的 YAML 应当解析失败")
+		_, err = decode("Preferences.toml", []byte("name = "))
+		assert.Error(t, err, "无效的 TOML 应当解析失败")
+	})
+
+	t.Run("Asset", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		assetFile := filepath.Join(tmpDir, "Preferences.toml")
+		assert.NoError(t, writeFile(assetFile, []byte(tomlData)))
+
+		reset()
+		os.Args = []string{"test", "--Prefs@Asset=" + assetFile, "--Prefs@Asset.name=Override"}
+		defer func() { os.Args = []string{"test"} }()
+		assert.Equal(t, "Override", Asset().GetString("name"), "命令行参数应当覆盖 TOML 格式的配置")
+		assert.Equal(t, 3, Asset().GetInt("count"))
+		assert.Equal(t, "Dark", Asset().Get("UI").(IBase).GetString("Theme"))
+	})
+
+	t.Run("Locate", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		file := filepath.Join(tmpDir, "Preferences.json")
+		assert.Equal(t, file, locate(file), "文件均不存在时应当返回原路径")
+
+		assert.NoError(t, writeFile(filepath.Join(tmpDir, "Preferences.yml"), []byte(yamlData)))
+		assert.Equal(t, filepath.Join(tmpDir, "Preferences.yml"), locate(file), "JSON 文件不存在时应当查找 YAML 文件")
+
+		assert.NoError(t, writeFile(file, []byte(jsonData)))
+		assert.Equal(t, file, locate(file), "JSON 文件存在时应当优先使用")
+	})
+
+	t.Run("Save", func(t *testing.T) {
+		for _, name := range []string{"Preferences.yaml", "Preferences.toml"} {
+			tmpDir := t.TempDir()
+			file := filepath.Join(tmpDir, name)
+			assert.NoError(t, writeFile(file, []byte(yamlData)))
+			if formatOf(name) == formatToml {
+				assert.NoError(t, writeFile(file, []byte(tomlData)))
+			}
+
+			pl := &prefsLocal{}
+			assert.True(t, pl.read(file))
+			pl.Set("count", 4)
+			assert.True(t, pl.Save())
+
+			data, err := readFile(file)
+			assert.NoError(t, err)
+			_, err = decode(file, data)
+			assert.NoError(t, err, "%v 应当保存为原有的格式", name)
+			assert.NotContains(t, string(data), "{\n", "%v 不应当保存为 JSON 格式", name)
+
+			saved := &prefsLocal{}
+			assert.True(t, saved.read(file))
+			assert.Equal(t, 4, saved.GetInt("count"))
+			assert.Equal(t, "Dark", saved.Get("UI").(IBase).GetString("Theme"))
+			assert.Equal(t, []string{"a", "b"}, saved.GetStrings("tags"))
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		file := filepath.Join(tmpDir, "Preferences.yaml")
+		assert.NoError(t, writeFile(file, []byte("name: [a")))
+		pl := &prefsLocal{}
+		assert.False(t, pl.read(file), "无效的 YAML 文件应当读取失败")
+	})
+}
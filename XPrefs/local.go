@@ -6,7 +6,6 @@ package XPrefs
 
 import (
 	"fmt"
-	"strings"
 )
 
 // localFile 本地首选项的默认路径.
@@ -15,20 +14,23 @@ const localFile = "Local/Preferences.json"
 // prefsLocal 管理可读写的本地首选项.
 type prefsLocal struct {
 	prefsBase
-	file string // 本地首选项文件路径.
+	file  string    // 本地首选项文件路径.
+	watch fileWatch // 本地首选项文件的变更检测.
 }
 
 // read 函数从指定的文件中读取偏好设置。
-// 如果没有指定文件，则从默认的本地偏好设置文件中读取。
+// 如果没有指定文件，则从默认的本地偏好设置文件中读取，默认的 JSON 文件不存在时依次查找 YAML 和 TOML 格式的文件。
+// 根据文件扩展名识别 JSON、YAML（.yaml、.yml）或 TOML（.toml）格式。
 // 如果文件读取成功，则返回 true，否则返回 false。
 func (pl *prefsLocal) read(file ...string) bool {
 	if len(file) > 0 {
 		pl.file = file[0]
 	} else {
-		pl.file = localFile
+		pl.file = locate(localFile)
 	}
 
 	fmt.Printf("XPrefs.Local.Read: reading %s.\n", pl.file)
+	pl.watch.mark(pl.file)
 
 	if !fileExists(pl.file) {
 		return false
@@ -39,43 +41,25 @@ func (pl *prefsLocal) read(file ...string) bool {
 		fmt.Printf("XPrefs.Local.Read: failed to read file %s: %v\n", pl.file, err)
 		return false
 	}
+	if data, err = decode(pl.file, data); err != nil {
+		fmt.Printf("XPrefs.Local.Read: failed to decode file %s: %v\n", pl.file, err)
+		return false
+	}
 
 	return pl.parse(data)
 }
 
-// parse 函数解析配置数据并应用命令行参数覆盖。
+// parse 函数解析配置数据并应用环境变量及命令行参数覆盖。
 // 输入字节数组形式的配置数据，解析成功返回 true，失败返回 false。
-// 解析完成后会检查命令行参数中以 "Prefs@Local." 开头的配置项，并用其值覆盖相应的配置。
+// 解析完成后会检查以 "XPREFS_LOCAL__" 开头的环境变量及以 "Prefs@Local." 开头的命令行参数，并用其值覆盖相应的配置。
 func (pl *prefsLocal) parse(data []byte) bool {
-	defer func() {
-		args := parseArgs()
-		for k, v := range args {
-			if strings.HasPrefix(k, "Prefs@Local.") {
-				key := strings.TrimPrefix(k, "Prefs@Local.")
-				if strings.Contains(key, ".") {
-					parts := strings.Split(key, ".")
-					current := (any)(pl).(IBase)
-					for i := 0; i < len(parts)-1; i++ {
-						part := parts[i]
-						if !current.Has(part) {
-							current.Set(part, New())
-						}
-						current = current.Get(part).(IBase)
-					}
-					current.Set(parts[len(parts)-1], v)
-				} else {
-					pl.Set(key, v)
-				}
-				fmt.Printf("XPrefs.Local.Parse: override %s = %s\n", key, v)
-			}
-		}
-	}()
+	defer override(pl, "Local", pl.overrides())
 
 	return pl.prefsBase.parse(data)
 }
 
 // Save 函数将偏好设置保存到指定的文件中。
-// 如果没有指定文件，则保存到默认的本地偏好设置文件中。
+// 如果没有指定文件，则保存到读取时的本地偏好设置文件中，并根据文件扩展名保存为对应的格式。
 // 如果文件保存成功，则返回 true，否则返回 false。
 func (pl *prefsLocal) Save(file ...string) bool {
 	sfile := pl.file
@@ -86,12 +70,19 @@ func (pl *prefsLocal) Save(file ...string) bool {
 		sfile = localFile
 	}
 
-	json := pl.Json(true)
-	err := writeFile(sfile, []byte(json))
+	data, err := encode(sfile, pl.Json(true))
+	if err != nil {
+		fmt.Printf("XPrefs.Local.Save: encode file err: %v\n", err)
+		return false
+	}
+	err = writeFile(sfile, data)
 	if err != nil {
 		fmt.Printf("XPrefs.Local.Save: save file err: %v\n", err)
 		return false
 	}
+	if sfile == pl.file {
+		pl.watch.mark(sfile) // 避免自身的写入触发重新读取
+	}
 	fmt.Printf("XPrefs.Local.Save: persisted to %s.\n", sfile)
 	return true
 }
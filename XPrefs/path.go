@@ -0,0 +1,207 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XPrefs
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// EscapeKey 转义键名中的 . 和 \，用于拼接配置路径，如 "Hosts." + EscapeKey("example.com") + ".Port"。
+func EscapeKey(key string) string {
+	return strings.NewReplacer(`\`, `\\`, `.`, `\.`).Replace(key)
+}
+
+// splitPath 将配置路径拆分为各级键名。
+// 路径使用 . 分隔各级配置，键名中的 . 和 \ 需分别转义为 \. 和 \\，键名中的 / 无需转义，如 Log/File.Level。
+// 路径为空、包含空的键名或以未完成的转义结尾时返回错误。
+func splitPath(path string) ([]string, error) {
+	if path == "" {
+		return nil, fmt.Errorf("empty path")
+	}
+	var parts []string
+	var part strings.Builder
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; c {
+		case '\\':
+			if i+1 >= len(path) || (path[i+1] != '.' && path[i+1] != '\\') {
+				return nil, fmt.Errorf("invalid escape at %v of %q", i, path)
+			}
+			i++
+			part.WriteByte(path[i])
+		case '.':
+			if part.Len() == 0 {
+				return nil, fmt.Errorf("empty key at %v of %q", i, path)
+			}
+			parts = append(parts, part.String())
+			part.Reset()
+		default:
+			part.WriteByte(c)
+		}
+	}
+	if part.Len() == 0 {
+		return nil, fmt.Errorf("empty key at end of %q", path)
+	}
+	return append(parts, part.String()), nil
+}
+
+// lookupPath 按照配置路径查找配置项的值，数组的元素使用数字索引，如 Servers.0.Host。
+// 返回配置项的值及是否存在。
+func (pb *prefsBase) lookupPath(path string) (any, bool) {
+	parts, err := splitPath(path)
+	if err != nil {
+		return nil, false
+	}
+	var current any = pb
+	for _, part := range parts {
+		if base := toBase(current); base != nil {
+			if !base.Has(part) {
+				return nil, false
+			}
+			current = base.Get(part)
+			continue
+		}
+		rv := reflect.ValueOf(current)
+		if rv.Kind() != reflect.Slice {
+			return nil, false
+		}
+		index, err := strconv.Atoi(part)
+		if err != nil || index < 0 || index >= rv.Len() {
+			return nil, false
+		}
+		current = rv.Index(index).Interface()
+		if m, ok := current.(map[string]any); ok {
+			current = &prefsBase{pairs: m}
+		}
+	}
+	return current, true
+}
+
+// parentPath 按照配置路径查找上级配置，create 为 true 时创建不存在的上级配置。
+// 返回上级配置及最后一级的键名，路径无效或上级配置不是配置实例时返回错误。
+func (pb *prefsBase) parentPath(path string, create bool) (IBase, string, error) {
+	parts, err := splitPath(path)
+	if err != nil {
+		return nil, "", err
+	}
+	var current IBase = pb
+	for i, part := range parts[:len(parts)-1] {
+		if !current.Has(part) {
+			if !create {
+				return nil, "", notFound(path)
+			}
+			child := New()
+			current.Set(part, child)
+			current = child
+			continue
+		}
+		next := toBase(current.Get(part))
+		if next == nil {
+			return nil, "", fmt.Errorf("%v of %q is not an object", strings.Join(parts[:i+1], "."), path)
+		}
+		current = next
+	}
+	return current, parts[len(parts)-1], nil
+}
+
+// HasPath 检查配置路径对应的配置项是否存在。
+// 路径使用 . 分隔各级配置，键名中的 . 和 \ 需转义，数组的元素使用数字索引。
+func (pb *prefsBase) HasPath(path string) bool {
+	_, ok := pb.lookupPath(path)
+	return ok
+}
+
+// GetPath 获取配置路径对应的配置项的值。
+// 路径使用 . 分隔各级配置，键名中的 . 和 \ 需转义，数组的元素使用数字索引，如 Log/File.Level、Servers.0.Host。
+// 如果配置项不存在且提供了默认值，则返回默认值；否则返回 nil。
+func (pb *prefsBase) GetPath(path string, defval ...any) any {
+	if val, ok := pb.lookupPath(path); ok {
+		return val
+	}
+	if len(defval) > 0 {
+		return defval[0]
+	}
+	return nil
+}
+
+// SetPath 设置配置路径对应的配置项的值，不存在的上级配置将被创建。
+// 路径无效或上级配置不是配置实例（如数组或字符串）时不做修改并输出错误。
+// 返回接口实例本身，支持链式调用。
+func (pb *prefsBase) SetPath(path string, value any) IBase {
+	parent, key, err := pb.parentPath(path, true)
+	if err != nil {
+		fmt.Printf("XPrefs.Base.SetPath: %v\n", err)
+		return pb
+	}
+	parent.Set(key, value)
+	return pb
+}
+
+// UnsetPath 删除配置路径对应的配置项，上级配置不会被删除。
+// 返回接口实例本身，支持链式调用。
+func (pb *prefsBase) UnsetPath(path string) IBase {
+	if parent, key, err := pb.parentPath(path, false); err == nil {
+		parent.Unset(key)
+	}
+	return pb
+}
+
+// GetPathAs 获取配置路径对应的配置项的值并转换为 T 类型，类型转换的规则与 Get 系列函数一致。
+// T 支持 int、float32、float64、bool、string 及其数组、IBase 和 any，如 GetPathAs[string](XPrefs.Asset(), "Log/File.Level")。
+// 如果配置项不存在或类型转换失败且提供了默认值，则返回默认值；否则返回零值。
+func GetPathAs[T any](prefs IBase, path string, defval ...T) T {
+	if prefs != nil && prefs.HasPath(path) {
+		if ret, err := sealed(coerceTo[T])(prefs.GetPath(path)); err == nil {
+			return ret
+		}
+	}
+	if len(defval) > 0 {
+		return defval[0]
+	}
+	var zero T
+	return zero
+}
+
+// coerceTo 将值转换为 T 类型。
+func coerceTo[T any](value any) (T, error) {
+	var zero T
+	var ret any
+	var err error
+	switch any(zero).(type) {
+	case int:
+		ret, err = coerceInt(value)
+	case float32:
+		ret, err = coerceFloat32(value)
+	case float64:
+		ret, err = coerceFloat(value)
+	case bool:
+		ret, err = coerceBool(value)
+	case string:
+		ret, err = coerceString(value)
+	case []int:
+		ret, err = coerceSlice(value, sealed(coerceInt))
+	case []float32:
+		ret, err = coerceSlice(value, sealed(coerceFloat32))
+	case []bool:
+		ret, err = coerceSlice(value, sealed(coerceBool))
+	case []string:
+		ret, err = coerceSlice(value, sealed(coerceString))
+	default:
+		if base := toBase(value); base != nil {
+			value = base
+		}
+		v, ok := value.(T)
+		if !ok {
+			return zero, cannot(value, reflect.TypeFor[T]().String())
+		}
+		return v, nil
+	}
+	if err != nil {
+		return zero, err
+	}
+	return ret.(T), nil
+}
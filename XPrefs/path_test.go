@@ -0,0 +1,135 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XPrefs
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPath(t *testing.T) {
+	data := `{
+		"Log/File": {"Level": "Info", "Rotate": true},
+		"Hosts": {"example.com": {"Port": 80}, "a\\b": 1},
+		"Servers": [{"Host": "a.local"}, {"Host": "b.local"}],
+		"Ports": [80, 443]
+	}`
+
+	t.Run("Split", func(t *testing.T) {
+		parts, err := splitPath(`Log/File.Level`)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Log/File", "Level"}, parts, "键名中的 / 无需转义")
+
+		parts, err = splitPath(`Hosts.example\.com.Port`)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Hosts", "example.com", "Port"}, parts, `\. 应当转义为 .`)
+
+		parts, err = splitPath(`a\\b`)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{`a\b`}, parts, `\\ 应当转义为 \`)
+
+		for _, path := range []string{"", ".a", "a.", "a..b", `a\`, `a\b`} {
+			_, err := splitPath(path)
+			assert.Error(t, err, "无效的路径 %q 应当报告错误", path)
+		}
+
+		assert.Equal(t, `example\.com`, EscapeKey("example.com"))
+		assert.Equal(t, `a\\b`, EscapeKey(`a\b`))
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		pb := &prefsBase{}
+		assert.True(t, pb.parse([]byte(data)))
+
+		assert.Equal(t, "Info", pb.GetPath("Log/File.Level"))
+		assert.Equal(t, float64(80), pb.GetPath("Hosts."+EscapeKey("example.com")+".Port"))
+		assert.Equal(t, float64(1), pb.GetPath(`Hosts.a\\b`))
+		assert.Equal(t, "b.local", pb.GetPath("Servers.1.Host"), "数组的元素应当使用数字索引")
+		assert.Equal(t, float64(443), pb.GetPath("Ports.1"))
+		assert.Nil(t, pb.GetPath("Ports.2"))
+		assert.Nil(t, pb.GetPath("Log/File.Level.Name"))
+		assert.Equal(t, "def", pb.GetPath("Log/File.Missing", "def"))
+		assert.True(t, pb.HasPath("Log/File.Rotate"))
+		assert.False(t, pb.HasPath("Log/Std.Level"))
+
+		assert.Equal(t, "Info", GetPathAs[string](pb, "Log/File.Level"))
+		assert.True(t, GetPathAs[bool](pb, "Log/File.Rotate"))
+		assert.Equal(t, 80, GetPathAs[int](pb, "Hosts.example\\.com.Port"))
+		assert.Equal(t, []int{80, 443}, GetPathAs[[]int](pb, "Ports"))
+		assert.Equal(t, "a.local", GetPathAs[IBase](pb, "Servers.0").GetString("Host"))
+		assert.Equal(t, 9, GetPathAs(pb, "Log/File.Level", 9), "转换失败时应当返回默认值")
+		assert.Equal(t, "", GetPathAs[string](nil, "Log/File.Level"))
+	})
+
+	t.Run("Set", func(t *testing.T) {
+		pb := &prefsBase{}
+		assert.True(t, pb.parse([]byte(data)))
+
+		file := pb.Get("Log/File").(IBase)
+		pb.SetPath("Log/File.Level", "Debug")
+		assert.Equal(t, "Debug", file.GetString("Level"), "缓存的多级配置应当与设置的值一致")
+		assert.Equal(t, []string{"Level", "Rotate"}, sorted(file.Keys()))
+
+		pb.SetPath("Log/File.Path", "/tmp")
+		assert.Equal(t, []string{"Level", "Path", "Rotate"}, sorted(file.Keys()), "新增的配置项不应当覆盖原有的键名列表")
+
+		pb.SetPath("Log/Std.Level", "Info")
+		assert.Equal(t, "Info", pb.GetPath("Log/Std.Level"), "不存在的上级配置应当被创建")
+
+		pb.SetPath("Ports.0", 8080)
+		assert.Equal(t, []any{float64(80), float64(443)}, pb.Get("Ports"), "上级配置不是配置实例时不应当修改")
+		pb.SetPath("Log/File.Level.Name", "x")
+		assert.Equal(t, "Debug", pb.GetPath("Log/File.Level"))
+
+		pb.Set("Log/File", map[string]any{"Level": "Error"})
+		assert.Equal(t, "Error", pb.Get("Log/File").(IBase).GetString("Level"), "Set 后应当返回新的值而不是缓存的值")
+
+		assert.JSONEq(t, `{"Level": "Info"}`, pb.Get("Log/Std").(IBase).Json(), "多级配置的实例应当被正确地序列化")
+		assert.Contains(t, pb.Json(), `"Log/Std":{"Level":"Info"}`)
+	})
+
+	t.Run("Unset", func(t *testing.T) {
+		pb := &prefsBase{}
+		assert.True(t, pb.parse([]byte(data)))
+
+		file := pb.Get("Log/File").(IBase)
+		pb.UnsetPath("Log/File.Level")
+		assert.False(t, pb.HasPath("Log/File.Level"))
+		assert.False(t, file.Has("Level"), "缓存的多级配置应当与删除的结果一致")
+		assert.True(t, pb.HasPath("Log/File.Rotate"), "上级配置不应当被删除")
+		pb.UnsetPath("Log/Std.Level")
+		pb.UnsetPath("Log/File")
+		assert.False(t, pb.Has("Log/File"))
+	})
+
+	t.Run("Sources", func(t *testing.T) {
+		originalArgs := os.Args
+		defer func() {
+			os.Args = originalArgs
+			reset()
+		}()
+		assetFile := filepath.Join(t.TempDir(), "Preferences.json")
+		assert.NoError(t, writeFile(assetFile, []byte(data)))
+		os.Args = []string{"test", "--Prefs@Asset=" + assetFile, `--Prefs.Hosts.example\.com.Port=8080`}
+		reset()
+
+		assert.Equal(t, "8080", GetPath("Hosts.example\\.com.Port"), "命令行参数应当支持转义的路径")
+		local := New().SetPath("Log/File.Level", "Debug")
+		assert.Equal(t, "Debug", GetPath("Log/File.Level", "", local))
+		assert.Equal(t, "Info", GetPath("Log/File.Level", ""))
+		assert.Equal(t, "def", GetPath("Log/File.Missing", "def", local))
+		assert.Equal(t, "Info", Asset().Eval("${Prefs.Log/File.Level}"))
+	})
+}
+
+// sorted 返回排序后的键名列表。
+func sorted(keys []string) []string {
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XPrefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// profileFunc 解析叠加配置名称的函数，由 SetProfile 注册。
+// 叠加配置在初始化及重新读取时解析，后者不持有 initMu，故使用原子操作读写。
+var profileFunc atomic.Pointer[func(get func(key, defval string) string) []string]
+
+// SetProfile 注册叠加配置名称的解析函数，叠加配置按照返回的顺序合并。
+// get 读取基础配置中的配置项（应用环境变量及命令行参数的覆盖后），不存在或为空时返回 defval；resolve 为 nil 时不叠加。
+// XEnv 在初始化时注册运行模式及渠道（XEnv.PrefsMode、XEnv.PrefsChannel），命令行参数 --Prefs@Profile 的优先级高于注册的解析函数。
+// 需要在首次调用 Asset 之前注册。
+func SetProfile(resolve func(get func(key, defval string) string) []string) {
+	if resolve == nil {
+		profileFunc.Store(nil)
+		return
+	}
+	profileFunc.Store(&resolve)
+}
+
+// tracer 定义了记录配置项来源的接口。
+type tracer interface {
+	trace(path, layer string)
+}
+
+// profiles 返回资产配置需要叠加的配置名称，默认由 SetProfile 注册的解析函数返回，未注册时不叠加。
+// 解析函数读取的是基础配置文件（应用环境变量及命令行参数的覆盖后），叠加配置中的修改不会影响叠加的顺序；
+// 可通过 --Prefs@Profile 参数指定叠加的配置名称，多个名称以逗号分隔，为空时不叠加。
+func profiles(kvs map[string]any, ovr *overrides) []string {
+	var names []string
+	if arg, ok := ovr.args["Prefs@Profile"]; ok {
+		names = strings.Split(arg, ",")
+	} else if resolve := profileFunc.Load(); resolve != nil {
+		names = (*resolve)(func(key, defval string) string { return profileValue(kvs, ovr, key, defval) })
+	}
+	var ret []string
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			ret = append(ret, name)
+		}
+	}
+	return ret
+}
+
+// profileValue 读取基础配置中的配置项，并按照 override 的顺序应用环境变量及命令行参数的覆盖。
+func profileValue(kvs map[string]any, ovr *overrides, key, defval string) string {
+	value, ok := kvs[key]
+	for _, scope := range []string{"", "Asset"} {
+		argPrefix := "Prefs."
+		if scope != "" {
+			argPrefix = "Prefs@" + scope + "."
+		}
+		if env, exists := ovr.envs[scope][key]; exists {
+			value, ok = env.value, true
+		}
+		if arg, exists := ovr.args[argPrefix+key]; exists {
+			value, ok = arg, true
+		}
+	}
+	if str, err := coerceString(value); ok && err == nil && str != "" {
+		return str
+	}
+	return defval
+}
+
+// profileFile 返回叠加配置的文件路径，如 Assets/Preferences.json 对应的 Assets/Preferences.Prod.json。
+// 叠加配置文件不存在时依次查找 YAML 和 TOML 格式的文件。
+func profileFile(file, name string) string {
+	ext := filepath.Ext(file)
+	return locate(strings.TrimSuffix(file, ext) + "." + name + ext)
+}
+
+// layer 读取资产配置的叠加配置，并按照基础配置、运行模式、渠道的顺序深度合并。
+// file 和 data 为基础配置文件的路径及 JSON 格式的数据，ovr 为覆盖配置项的命令行参数及环境变量，不存在的叠加配置文件将被忽略。
+// 返回合并后的 JSON 数据、配置项的来源及叠加配置文件的路径，叠加配置文件读取或解析失败时返回错误。
+func layer(file string, data []byte, ovr *overrides) ([]byte, map[string]string, []string, error) {
+	var kvs map[string]any
+	if err := json.Unmarshal(data, &kvs); err != nil || kvs == nil {
+		return data, nil, nil, nil // 由 parse 报告无效的数据
+	}
+	origins := make(map[string]string, len(kvs))
+	for key := range kvs {
+		origins[EscapeKey(key)] = file
+	}
+
+	var files []string
+	merged := false
+	for _, name := range profiles(kvs, ovr) {
+		if strings.Contains(name, "${") {
+			fmt.Printf("XPrefs.Asset.Layer: skip profile %s which references variables.\n", name)
+			continue
+		}
+		pfile := profileFile(file, name)
+		if pfile == file || containsString(files, pfile) {
+			continue
+		}
+		files = append(files, pfile)
+		if !fileExists(pfile) {
+			continue
+		}
+		pdata, err := readFile(pfile)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("read profile %s error: %v", pfile, err)
+		}
+		if pdata, err = decode(pfile, pdata); err != nil {
+			return nil, nil, nil, fmt.Errorf("decode profile %s error: %v", pfile, err)
+		}
+		var pkvs map[string]any
+		if len(pdata) > 0 {
+			if err = json.Unmarshal(pdata, &pkvs); err != nil {
+				return nil, nil, nil, fmt.Errorf("unmarshal profile %s error: %v", pfile, err)
+			}
+		}
+		fmt.Printf("XPrefs.Asset.Layer: merging %s.\n", pfile)
+		merge(kvs, pkvs, "", pfile, origins)
+		merged = true
+	}
+	if !merged {
+		return data, origins, files, nil
+	}
+	ret, err := json.Marshal(kvs)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("marshal profiles error: %v", err)
+	}
+	return ret, origins, files, nil
+}
+
+// merge 将叠加配置深度合并到目标配置中，并记录配置项的来源。
+// 对象逐级合并，数组及其他类型的值直接替换，值为 null 时删除目标配置中的配置项。
+func merge(dst, src map[string]any, prefix, layer string, origins map[string]string) {
+	for key, value := range src {
+		path := EscapeKey(key)
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+		if value == nil {
+			delete(dst, key)
+			untrace(origins, path)
+			continue
+		}
+		if sm, ok := value.(map[string]any); ok {
+			if dm, ok := dst[key].(map[string]any); ok {
+				merge(dm, sm, path, layer, origins)
+				continue
+			}
+		}
+		dst[key] = value
+		untrace(origins, path)
+		origins[path] = layer
+	}
+}
+
+// untrace 删除配置路径及其下级配置的来源。
+func untrace(origins map[string]string, path string) {
+	delete(origins, path)
+	for key := range origins {
+		if strings.HasPrefix(key, path+".") {
+			delete(origins, key)
+		}
+	}
+}
+
+// joinPath 转义各级键名并拼接为配置路径。
+func joinPath(parts []string) string {
+	escaped := make([]string, len(parts))
+	for i, part := range parts {
+		escaped[i] = EscapeKey(part)
+	}
+	return strings.Join(escaped, ".")
+}
+
+// containsString 检查字符串数组中是否包含指定的字符串。
+func containsString(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
+// trace 记录配置路径的来源，下级配置的来源将被删除。
+func (pb *prefsBase) trace(path, layer string) {
+	parts, err := splitPath(path)
+	if err != nil {
+		return
+	}
+	path = joinPath(parts)
+	pb.Lock()
+	defer pb.Unlock()
+	if pb.origins == nil {
+		pb.origins = make(map[string]string)
+	}
+	untrace(pb.origins, path)
+	pb.origins[path] = layer
+}
+
+// explain 返回配置路径的来源，多个来源按照名称排序并以逗号分隔。
+// 依次查找配置路径自身或最近的上级配置的来源，以及下级配置的来源。
+func (pb *prefsBase) explain(path string) string {
+	if !pb.HasPath(path) {
+		return ""
+	}
+	parts, _ := splitPath(path)
+	full := joinPath(parts)
+
+	pb.RLock()
+	defer pb.RUnlock()
+	var layers []string
+	for i := len(parts); i > 0; i-- {
+		if layer, ok := pb.origins[joinPath(parts[:i])]; ok {
+			layers = append(layers, layer)
+			break
+		}
+	}
+	for key, layer := range pb.origins {
+		if strings.HasPrefix(key, full+".") && !containsString(layers, layer) {
+			layers = append(layers, layer)
+		}
+	}
+	sort.Strings(layers)
+	return strings.Join(layers, ", ")
+}
+
+// Explain 返回资产配置中配置项的来源，用于排查叠加配置的合并结果。
+// key 为配置路径，如 Log/File.Level；来源为配置文件的路径、覆盖配置项的环境变量（如 env XPREFS__Key）或命令行参数（如 arg --Prefs.Key）。
+// 多级配置的值来自多个来源时按照名称排序并以逗号分隔；配置项不存在时返回空字符串，通过 Set 修改配置项不会更新来源。
+func Explain(key string) string {
+	return Asset().explain(key)
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XPrefs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfile(t *testing.T) {
+	originalArgs := os.Args
+	defer func() {
+		os.Args = originalArgs
+		SetProfile(nil)
+		reset()
+	}()
+	os.Args = []string{"test"}
+
+	// 与 XEnv 注册的解析函数一致，依次叠加运行模式及渠道的配置
+	SetProfile(func(get func(key, defval string) string) []string {
+		return []string{get("Env/Mode", "Dev"), get("Env/Channel", "Default")}
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		dst := map[string]any{
+			"Name": "base",
+			"Log":  map[string]any{"Level": "Info", "Color": true},
+			"Tags": []any{"a", "b"},
+			"Drop": 1,
+		}
+		origins := map[string]string{"Name": "base", "Log": "base", "Tags": "base", "Drop": "base"}
+		merge(dst, map[string]any{
+			"Log":      map[string]any{"Level": "Debug", "Color": nil},
+			"Tags":     []any{"c"},
+			"Drop":     nil,
+			"Host.com": "example",
+		}, "", "prod", origins)
+
+		assert.Equal(t, map[string]any{
+			"Name":     "base",
+			"Log":      map[string]any{"Level": "Debug"},
+			"Tags":     []any{"c"},
+			"Host.com": "example",
+		}, dst, "对象应当逐级合并，数组应当直接替换，null 应当删除配置项")
+		assert.Equal(t, map[string]string{
+			"Name":      "base",
+			"Log":       "base",
+			"Log.Level": "prod",
+			"Tags":      "prod",
+			`Host\.com`: "prod",
+		}, origins, "应当记录合并后配置项的来源")
+	})
+
+	t.Run("Layer", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		assetFile := filepath.Join(tmpDir, "Preferences.json")
+		prodFile := filepath.Join(tmpDir, "Preferences.Prod.json")
+		steamFile := filepath.Join(tmpDir, "Preferences.Steam.yaml")
+		assert.NoError(t, writeFile(assetFile, []byte(`{
+			"Env/Mode": "Prod",
+			"Env/Channel": "Steam",
+			"Name": "base",
+			"Debug": true,
+			"Log/File": {"Level": "Debug", "Path": "logs"}
+		}`)))
+		assert.NoError(t, writeFile(prodFile, []byte(`{"Name": "prod", "Debug": null, "Log/File": {"Level": "Info"}}`)))
+		assert.NoError(t, writeFile(steamFile, []byte("Name: steam\nSteam/AppId: 480\n")))
+
+		reset()
+		os.Args = []string{"test", "--Prefs@Asset=" + assetFile}
+		defer func() { os.Args = []string{"test"} }()
+
+		assert.Equal(t, "steam", Asset().GetString("Name"), "渠道的叠加配置应当覆盖运行模式的叠加配置")
+		assert.False(t, Asset().Has("Debug"), "叠加配置中的 null 应当删除配置项")
+		assert.Equal(t, "Info", Asset().GetPath("Log/File.Level"), "运行模式的叠加配置应当覆盖基础配置")
+		assert.Equal(t, "logs", Asset().GetPath("Log/File.Path"), "对象中未覆盖的配置项应当保留")
+		assert.Equal(t, 480, Asset().GetInt("Steam/AppId"), "应当支持其他格式的叠加配置文件")
+
+		assert.Equal(t, steamFile, Explain("Name"))
+		assert.Equal(t, prodFile, Explain("Log/File.Level"))
+		assert.Equal(t, assetFile, Explain("Log/File.Path"), "应当返回最近的上级配置的来源")
+		assert.Equal(t, assetFile, Explain("Env/Mode"))
+		assert.Contains(t, Explain("Log/File"), assetFile, "多级配置应当包含所有来源")
+		assert.Contains(t, Explain("Log/File"), prodFile, "多级配置应当包含所有来源")
+		assert.Equal(t, "", Explain("Debug"), "不存在的配置项不应当返回来源")
+	})
+
+	t.Run("Override", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		assetFile := filepath.Join(tmpDir, "Preferences.json")
+		assert.NoError(t, writeFile(assetFile, []byte(`{"Name": "base", "Count": 1, "Log": {"Level": "Info"}}`)))
+		assert.NoError(t, writeFile(filepath.Join(tmpDir, "Preferences.Dev.json"), []byte(`{"Name": "dev"}`)))
+		assert.NoError(t, writeFile(filepath.Join(tmpDir, "Preferences.Test.json"), []byte(`{"Name": "test"}`)))
+		t.Setenv("XPREFS__Count", "2")
+
+		reset()
+		os.Args = []string{"test", "--Prefs@Asset=" + assetFile, "--Prefs.Log.Level=Debug"}
+		defer func() { os.Args = []string{"test"} }()
+		assert.Equal(t, "dev", Asset().GetString("Name"), "未配置运行模式时应当叠加默认运行模式的配置")
+		assert.Equal(t, "env XPREFS__Count", Explain("Count"), "应当记录覆盖配置项的环境变量")
+		assert.Equal(t, "arg --Prefs.Log.Level", Explain("Log.Level"), "应当记录覆盖配置项的命令行参数")
+		assert.Equal(t, assetFile+", arg --Prefs.Log.Level", Explain("Log"), "多级配置的来源应当按照名称排序")
+
+		reset()
+		os.Args = []string{"test", "--Prefs@Asset=" + assetFile, "--Prefs.Env/Mode=Test"}
+		assert.Equal(t, "test", Asset().GetString("Name"), "命令行参数指定的运行模式应当用于选择叠加配置")
+
+		reset()
+		os.Args = []string{"test", "--Prefs@Asset=" + assetFile, "--Prefs@Profile="}
+		assert.Equal(t, "base", Asset().GetString("Name"), "叠加配置的名称为空时不应当叠加")
+
+		SetProfile(nil)
+		reset()
+		os.Args = []string{"test", "--Prefs@Asset=" + assetFile}
+		assert.Equal(t, "base", Asset().GetString("Name"), "未注册解析函数时不应当叠加")
+
+		reset()
+		os.Args = []string{"test", "--Prefs@Asset=" + assetFile, "--Prefs@Profile=Test"}
+		assert.Equal(t, "test", Asset().GetString("Name"), "未注册解析函数时应当支持命令行参数指定的叠加配置")
+
+		SetProfile(func(get func(key, defval string) string) []string { return []string{get("Env/Mode", "Dev")} })
+	})
+
+	t.Run("Reload", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		assetFile := filepath.Join(tmpDir, "Preferences.json")
+		prodFile := filepath.Join(tmpDir, "Preferences.Prod.json")
+		assert.NoError(t, writeFile(assetFile, []byte(`{"Prefs/Watch/Interval": 10, "Name": "base"}`)))
+
+		reset()
+		os.Args = []string{"test", "--Prefs@Asset=" + assetFile, "--Prefs@Profile=Prod"}
+		defer func() {
+			reset() // 停止监听协程后再修改命令行参数
+			os.Args = []string{"test"}
+		}()
+		assert.Equal(t, "base", Asset().GetString("Name"))
+
+		assert.NoError(t, writeFile(prodFile, []byte(`{"Name": "prod"}`)))
+		assert.Eventually(t, func() bool { return Asset().GetString("Name") == "prod" }, time.Second, time.Millisecond*10,
+			"叠加配置文件创建后应当重新读取")
+		assert.Equal(t, prodFile, Explain("Name"))
+
+		assert.NoError(t, writeFile(prodFile, []byte(`{"Name": }`)))
+		time.Sleep(time.Millisecond * 100)
+		assert.Equal(t, "prod", Asset().GetString("Name"), "无效的叠加配置文件应当被拒绝并保留原有的配置")
+	})
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XPrefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// prefsRemoteTimeout 远程配置单次请求的超时时间（毫秒）的配置键。
+	prefsRemoteTimeout = "Prefs/Remote/Timeout"
+	// prefsRemoteTimeoutDefault 远程配置单次请求的超时时间的默认值。
+	prefsRemoteTimeoutDefault = 5000
+	// prefsRemoteRetry 远程配置请求失败后的重试次数的配置键。
+	prefsRemoteRetry = "Prefs/Remote/Retry"
+	// prefsRemoteRetryDefault 远程配置请求失败后的重试次数的默认值。
+	prefsRemoteRetryDefault = 2
+)
+
+// remoteFile 远程首选项缓存文件的名称.
+const remoteFile = "Preferences.Remote.json"
+
+var (
+	// remoteOnce 确保远程配置只加载一次的同步控制。
+	remoteOnce sync.Once
+
+	// remote 全局远程配置实例。
+	remote atomic.Pointer[prefsRemote]
+
+	// remoteURL 解析远程配置地址的函数，由 SetRemote 注册。
+	remoteURL func() string
+
+	// remoteCache 解析远程配置缓存目录的函数，由 SetRemote 注册。
+	remoteCache func() string
+
+	// remoteDelay 远程配置请求失败后的重试间隔，逐次递增。
+	remoteDelay = time.Millisecond * 500
+)
+
+// prefsRemote 管理只读的远程首选项.
+type prefsRemote struct {
+	prefsBase
+	url      string // 远程首选项的地址.
+	file     string // 远程首选项的缓存文件路径.
+	etag     string // 最近一次响应的 ETag.
+	modified string // 最近一次响应的 Last-Modified.
+	cached   bool   // 是否使用了缓存的首选项.
+}
+
+// remoteCacheData 定义了远程首选项缓存文件的内容。
+type remoteCacheData struct {
+	URL      string          `json:"url"`      // 远程首选项的地址
+	ETag     string          `json:"etag"`     // 响应的 ETag
+	Modified string          `json:"modified"` // 响应的 Last-Modified
+	Data     json.RawMessage `json:"data"`     // 首选项的内容
+}
+
+// SetRemote 注册远程配置地址与缓存目录的解析函数。
+// addr 返回远程配置的 HTTP(S) 地址，cacheDir 返回缓存文件所在的目录，为 nil 时使用默认值。
+// XEnv 在初始化时注册 XEnv.Remote 和 XEnv.LocalPath，命令行参数 --Prefs@Remote 的优先级高于注册的地址。
+// 需要在首次调用 Remote 之前注册。
+func SetRemote(addr func() string, cacheDir func() string) {
+	initMu.Lock()
+	defer initMu.Unlock()
+	remoteURL = addr
+	remoteCache = cacheDir
+}
+
+// Remote 获取远程配置实例。
+// 返回一个只读的远程配置实例，首次调用时通过 HTTP(S) 拉取远程配置，失败时回退至本地缓存。
+// 推荐的查找顺序为 Local、Remote、Asset，如 XPrefs.GetString(key, defval, XPrefs.Local(), XPrefs.Remote())。
+func Remote() *prefsRemote {
+	initOnce.Do(setup)
+	remoteOnce.Do(setupRemote)
+	return remote.Load()
+}
+
+// setupRemote 初始化远程配置。
+// 地址优先读取命令行参数 --Prefs@Remote，其次为 SetRemote 注册的地址；缓存目录默认为 Local。
+func setupRemote() {
+	initMu.Lock()
+	urlFunc, cacheFunc := remoteURL, remoteCache
+	initMu.Unlock()
+
+	addr := parseArgs()["Prefs@Remote"]
+	if addr == "" && urlFunc != nil {
+		addr = urlFunc()
+	}
+	dir := filepath.Dir(localFile)
+	if cacheFunc != nil {
+		dir = cacheFunc()
+	}
+
+	pr := &prefsRemote{}
+	pr.read(addr, filepath.Join(dir, remoteFile))
+	report("Remote", validate(pr))
+	remote.Store(pr)
+}
+
+// read 函数从指定的地址拉取偏好设置。
+// 请求携带缓存的 ETag 和 Last-Modified，服务端返回 304 时使用缓存的内容。
+// 请求失败时按照 Prefs/Remote/Retry 重试，仍失败则回退至缓存文件，以支持离线启动。
+// 如果读取成功（包括回退至缓存），则返回 true，否则返回 false。
+func (pr *prefsRemote) read(addr string, file string) bool {
+	pr.url = addr
+	pr.file = file
+
+	var cache remoteCacheData
+	if fileExists(file) {
+		if data, err := readFile(file); err != nil {
+			fmt.Printf("XPrefs.Remote.Read: failed to read cache %s: %v\n", file, err)
+		} else if err := json.Unmarshal(data, &cache); err != nil {
+			fmt.Printf("XPrefs.Remote.Read: failed to unmarshal cache %s: %v\n", file, err)
+		} else if cache.URL == addr {
+			pr.etag = cache.ETag
+			pr.modified = cache.Modified
+		} else {
+			cache = remoteCacheData{}
+		}
+	}
+
+	if u, err := url.Parse(addr); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		fmt.Printf("XPrefs.Remote.Read: invalid url of %q.\n", addr)
+	} else {
+		fmt.Printf("XPrefs.Remote.Read: requesting %s.\n", addr)
+		retry := Asset().GetInt(prefsRemoteRetry, prefsRemoteRetryDefault)
+		client := &http.Client{Timeout: time.Duration(Asset().GetInt(prefsRemoteTimeout, prefsRemoteTimeoutDefault)) * time.Millisecond}
+		for i := 0; i <= retry; i++ {
+			if i > 0 {
+				time.Sleep(remoteDelay * time.Duration(i))
+			}
+			data, notModified, err := pr.fetch(client, len(cache.Data) > 0)
+			if err != nil {
+				fmt.Printf("XPrefs.Remote.Read: request %s failed(%v/%v): %v\n", addr, i+1, retry+1, err)
+				continue
+			}
+			if notModified {
+				pr.cached = true
+				fmt.Printf("XPrefs.Remote.Read: %s is not modified, using cache %s.\n", addr, file)
+				return pr.parse(cache.Data)
+			}
+			cache = remoteCacheData{URL: addr, ETag: pr.etag, Modified: pr.modified, Data: data}
+			if cdata, err := json.Marshal(cache); err != nil {
+				fmt.Printf("XPrefs.Remote.Read: failed to marshal cache: %v\n", err)
+			} else if err := writeFile(file, cdata); err != nil {
+				fmt.Printf("XPrefs.Remote.Read: failed to write cache %s: %v\n", file, err)
+			}
+			return pr.parse(data)
+		}
+	}
+
+	if len(cache.Data) == 0 {
+		fmt.Printf("XPrefs.Remote.Read: no cache of %s was found.\n", addr)
+		return pr.parse(nil)
+	}
+	pr.cached = true
+	fmt.Printf("XPrefs.Remote.Read: fallback to cache %s.\n", file)
+	return pr.parse(cache.Data)
+}
+
+// fetch 函数发起一次远程首选项的请求。
+// conditional 表示是否携带条件请求头，返回响应内容、是否未修改及请求过程中发生的错误。
+// 响应内容须为合法的 JSON 对象，否则视为请求失败。
+func (pr *prefsRemote) fetch(client *http.Client, conditional bool) ([]byte, bool, error) {
+	req, err := http.NewRequest(http.MethodGet, pr.url, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	if conditional {
+		if pr.etag != "" {
+			req.Header.Set("If-None-Match", pr.etag)
+		}
+		if pr.modified != "" {
+			req.Header.Set("If-Modified-Since", pr.modified)
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && conditional {
+		return nil, true, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("unexpected status of %v", resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, err
+	}
+	var kvs map[string]any
+	if err := json.Unmarshal(data, &kvs); err != nil {
+		return nil, false, fmt.Errorf("unmarshal error: %v", err)
+	}
+	pr.etag = resp.Header.Get("ETag")
+	pr.modified = resp.Header.Get("Last-Modified")
+	return data, false, nil
+}
+
+// parse 函数解析配置数据并应用环境变量及命令行参数覆盖。
+// 输入字节数组形式的配置数据，解析成功返回 true，失败返回 false。
+// 解析完成后会检查以 "XPREFS_REMOTE__" 开头的环境变量及以 "Prefs@Remote." 开头的命令行参数，并用其值覆盖相应的配置。
+func (pr *prefsRemote) parse(data []byte) bool {
+	defer override(pr, "Remote", pr.overrides())
+
+	return pr.prefsBase.parse(data)
+}
+
+// URL 返回远程首选项的地址。
+func (pr *prefsRemote) URL() string { return pr.url }
+
+// Cached 返回远程首选项是否来自本地缓存，即服务端返回 304 或请求失败后回退至缓存。
+func (pr *prefsRemote) Cached() bool { return pr.cached }
@@ -0,0 +1,151 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XPrefs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemote(t *testing.T) {
+	originalArgs := os.Args
+	originalDelay := remoteDelay
+	defer func() {
+		os.Args = originalArgs
+		remoteDelay = originalDelay
+		SetRemote(nil, nil)
+		reset()
+	}()
+	os.Args = []string{"test"}
+	remoteDelay = time.Millisecond * 10
+
+	var requests atomic.Int32
+	var failures atomic.Int32
+	var conditional atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if failures.Load() > 0 {
+			failures.Add(-1)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			conditional.Store(true)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"name": "RemoteName", "count": 3}`))
+	}))
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+	cacheFile := filepath.Join(cacheDir, remoteFile)
+	addr := server.URL + "/Preferences.json"
+	SetRemote(func() string { return addr }, func() string { return cacheDir })
+
+	t.Run("Fetch", func(t *testing.T) {
+		reset()
+		requests.Store(0)
+		assert.Equal(t, addr, Remote().URL())
+		assert.False(t, Remote().Cached(), "首次拉取不应当使用缓存")
+		assert.Equal(t, "RemoteName", Remote().GetString("name"))
+		assert.Equal(t, 3, Remote().GetInt("count"))
+		assert.Equal(t, int32(1), requests.Load(), "远程配置应当只拉取一次")
+		assert.FileExists(t, cacheFile, "拉取成功后应当写入缓存文件")
+	})
+
+	t.Run("Not Modified", func(t *testing.T) {
+		reset()
+		conditional.Store(false)
+		assert.Equal(t, "RemoteName", Remote().GetString("name"))
+		assert.True(t, conditional.Load(), "应当携带缓存的 ETag 发起条件请求")
+		assert.True(t, Remote().Cached(), "服务端返回 304 时应当使用缓存")
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		reset()
+		os.Remove(cacheFile)
+		requests.Store(0)
+		failures.Store(2)
+		assert.Equal(t, "RemoteName", Remote().GetString("name"), "重试成功后应当读取远程配置")
+		assert.Equal(t, int32(3), requests.Load(), "请求失败后应当按照 Prefs/Remote/Retry 重试")
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		reset()
+		Asset().Set(prefsRemoteTimeout, 50).Set(prefsRemoteRetry, 0)
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(time.Millisecond * 200)
+			w.Write([]byte(`{"name": "SlowName"}`))
+		}))
+		defer slow.Close()
+		os.Args = []string{"test", "--Prefs@Remote=" + slow.URL}
+		defer func() { os.Args = []string{"test"} }()
+
+		start := time.Now()
+		assert.Equal(t, slow.URL, Remote().URL(), "命令行参数应当覆盖注册的地址")
+		assert.Less(t, time.Since(start), time.Millisecond*200, "请求应当在超时后返回")
+		assert.False(t, Remote().Has("name"), "地址不同的缓存不应当被使用")
+	})
+
+	t.Run("Offline", func(t *testing.T) {
+		reset()
+		Remote()
+		server.Close()
+
+		reset()
+		requests.Store(0)
+		assert.Equal(t, "RemoteName", Remote().GetString("name"), "请求失败时应当回退至缓存")
+		assert.True(t, Remote().Cached())
+		assert.Equal(t, int32(0), requests.Load())
+	})
+
+	t.Run("Priority", func(t *testing.T) {
+		reset()
+		Asset().Set("name", "AssetName").Set("level", 1)
+		Local().Set("name", "LocalName")
+		assert.Equal(t, "LocalName", GetString("name", "", Local(), Remote()))
+		Local().Unset("name")
+		assert.Equal(t, "RemoteName", GetString("name", "", Local(), Remote()), "远程配置应当优先于资产配置")
+		assert.Equal(t, 1, GetInt("level", 0, Local(), Remote()), "远程配置未找到时应当查找资产配置")
+	})
+
+	t.Run("Lookup", func(t *testing.T) {
+		reset()
+		Asset().Set("name", "AssetName").Set("level", 1)
+		assert.Equal(t, "AssetName", GetString("name", ""), "远程配置未加载时不应当参与查找")
+
+		Remote()
+		assert.Equal(t, "RemoteName", GetString("name", ""), "远程配置加载后应当优先于资产配置")
+		assert.Equal(t, 3, GetInt("count", 0), "应当读取仅存在于远程配置中的配置项")
+		count, err := TryInt("count")
+		assert.NoError(t, err)
+		assert.Equal(t, 3, count, "应当读取仅存在于远程配置中的配置项")
+		assert.Equal(t, "RemoteName", Get("name"))
+		assert.True(t, HasKey("count"))
+		assert.Equal(t, 1, GetInt("level", 0), "远程配置未找到时应当查找资产配置")
+
+		Local().Set("name", "LocalName")
+		defer Local().Unset("name")
+		assert.Equal(t, "LocalName", GetString("name", "", Local()), "应当依次查找本地配置、远程配置及资产配置")
+		assert.Equal(t, 3, GetInt("count", 0, Local()), "本地配置未找到时应当查找远程配置")
+	})
+
+	t.Run("Invalid URL", func(t *testing.T) {
+		reset()
+		os.Args = []string{"test", "--Prefs@Remote=${Env.OssPublic}(Unknown)/Preferences.json"}
+		defer func() { os.Args = []string{"test"} }()
+		assert.Empty(t, Remote().Keys(), "无效的地址应当返回空的配置")
+		assert.False(t, Remote().Cached())
+	})
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XPrefs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	TypeString  = "string"  // 字符串类型
+	TypeInteger = "integer" // 整数类型，支持可解析为整数的字符串
+	TypeNumber  = "number"  // 数值类型，支持可解析为数值的字符串
+	TypeBoolean = "boolean" // 布尔类型，支持可转换为布尔值的字符串，参见 GetBool
+	TypeArray   = "array"   // 数组类型
+	TypeObject  = "object"  // 对象类型，即多级配置
+)
+
+// Schema 定义了配置项的校验规则，为 JSON Schema 的子集。
+type Schema struct {
+	Type   string                // 值的类型，参见 TypeString 等常量，为空时不校验类型
+	Enum   []any                 // 可选值列表，为空时不校验
+	Range  []float64             // 数值的范围 [最小值, 最大值]，可使用 math.Inf 表示不限，为空时不校验
+	Fields map[string]*Schema    // 对象的字段规则，不为空时同时报告未声明的字段
+	Items  *Schema               // 数组元素的规则
+	Check  func(value any) error // 自定义的校验函数
+}
+
+var (
+	// schemaMu 用于保护校验规则的读写锁。
+	schemaMu sync.RWMutex
+
+	// schemas 按键名前缀注册的校验规则。
+	schemas = map[string]map[string]*Schema{}
+)
+
+func init() {
+	RegisterSchema("Prefs/", map[string]*Schema{
+		prefsRemoteTimeout: {Type: TypeInteger, Range: []float64{1, math.Inf(1)}},
+		prefsRemoteRetry:   {Type: TypeInteger, Range: []float64{0, math.Inf(1)}},
+		prefsWatchInterval: {Type: TypeInteger},
+	})
+}
+
+// RegisterSchema 注册配置项的校验规则。
+// prefix 为键名前缀，keys 为该前缀下所有配置项的规则，键名须以 prefix 开头；以 prefix 开头但未声明的配置项将被报告为未知的配置项。
+// 配置读取及重新读取时均会校验，重新读取的配置校验失败时将被拒绝并保留原有的配置。
+// 注册时如果配置已经读取，则立即校验资产配置和本地配置。
+func RegisterSchema(prefix string, keys map[string]*Schema) {
+	if prefix == "" {
+		fmt.Printf("XPrefs.RegisterSchema: prefix can not be empty.\n")
+		return
+	}
+	rules := make(map[string]*Schema, len(keys))
+	for key, schema := range keys {
+		if !strings.HasPrefix(key, prefix) {
+			fmt.Printf("XPrefs.RegisterSchema: key %v does not start with prefix %v.\n", key, prefix)
+			continue
+		}
+		rules[key] = schema
+	}
+
+	schemaMu.Lock()
+	schemas[prefix] = rules
+	schemaMu.Unlock()
+
+	initMu.Lock()
+	pa, pl := asset, local
+	initMu.Unlock()
+	if pa != nil {
+		report("Asset", validate(pa, prefix))
+	}
+	if pl != nil {
+		report("Local", validate(pl, prefix))
+	}
+}
+
+// Validate 使用所有已注册的校验规则校验配置。
+// 返回所有未知的配置项、类型错误及超出范围的值合并后的错误，校验通过时返回 nil。
+func Validate(prefs IBase) error {
+	if prefs == nil || reflect.ValueOf(prefs).IsNil() {
+		return fmt.Errorf("XPrefs.Validate: prefs can not be nil")
+	}
+	return errors.Join(validate(prefs)...)
+}
+
+// validate 使用指定前缀的校验规则校验配置，未指定前缀时使用所有已注册的校验规则。
+func validate(prefs IBase, prefixes ...string) []error {
+	schemaMu.RLock()
+	defer schemaMu.RUnlock()
+
+	if len(prefixes) == 0 {
+		for prefix := range schemas {
+			prefixes = append(prefixes, prefix)
+		}
+		sort.Strings(prefixes)
+	}
+
+	keys := prefs.Keys()
+	sort.Strings(keys)
+	var errs []error
+	for _, prefix := range prefixes {
+		rules := schemas[prefix]
+		for _, key := range keys {
+			if !strings.HasPrefix(key, prefix) {
+				continue
+			}
+			schema, ok := rules[key]
+			if !ok {
+				errs = append(errs, fmt.Errorf("XPrefs.Validate: %v: unknown key", key))
+				continue
+			}
+			schema.validate(prefs.Get(key), key, &errs)
+		}
+	}
+	return errs
+}
+
+// report 输出校验失败的错误。
+func report(source string, errs []error) {
+	for _, err := range errs {
+		fmt.Printf("XPrefs.%v.Validate: %v\n", source, strings.TrimPrefix(err.Error(), "XPrefs.Validate: "))
+	}
+}
+
+// validate 校验配置项的值，path 为配置项的路径，发生的错误追加至 errs 中。
+func (s *Schema) validate(value any, path string, errs *[]error) {
+	if s == nil || IsEncrypted(value) {
+		return // 加密的值无法在不暴露明文的情况下校验
+	}
+	fail := func(format string, args ...any) {
+		*errs = append(*errs, fmt.Errorf("XPrefs.Validate: %v: %v", path, fmt.Sprintf(format, args...)))
+	}
+
+	if s.Type != "" && !matchType(s.Type, value) {
+		fail("expect %v but got %T(%v)", s.Type, value, value)
+		return
+	}
+
+	if len(s.Enum) > 0 {
+		found := false
+		for _, e := range s.Enum {
+			if equal(e, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fail("value %v is not one of %v", value, s.Enum)
+		}
+	}
+
+	if len(s.Range) == 2 {
+		if n, err := coerceFloat(value); err == nil && (n < s.Range[0] || n > s.Range[1]) {
+			fail("value %v is out of range [%v, %v]", value, s.Range[0], s.Range[1])
+		}
+	}
+
+	if len(s.Fields) > 0 {
+		if prefs := toBase(value); prefs != nil {
+			keys := prefs.Keys()
+			sort.Strings(keys)
+			for _, key := range keys {
+				field, ok := s.Fields[key]
+				if !ok {
+					*errs = append(*errs, fmt.Errorf("XPrefs.Validate: %v.%v: unknown key", path, key))
+					continue
+				}
+				field.validate(prefs.Get(key), path+"."+key, errs)
+			}
+		}
+	}
+
+	if s.Items != nil {
+		if rv := reflect.ValueOf(value); rv.Kind() == reflect.Slice {
+			for i := 0; i < rv.Len(); i++ {
+				s.Items.validate(rv.Index(i).Interface(), fmt.Sprintf("%v[%v]", path, i), errs)
+			}
+		}
+	}
+
+	if s.Check != nil {
+		if err := s.Check(value); err != nil {
+			fail("%v", err)
+		}
+	}
+}
+
+// matchType 检查值是否符合指定的类型，数值及布尔类型与 Get 系列函数的转换规则一致。
+func matchType(typ string, value any) bool {
+	switch typ {
+	case TypeString:
+		_, ok := value.(string)
+		return ok
+	case TypeInteger:
+		_, err := coerceInt(value)
+		return err == nil
+	case TypeNumber:
+		_, err := coerceFloat(value)
+		return err == nil
+	case TypeBoolean:
+		_, err := coerceBool(value)
+		return err == nil
+	case TypeArray:
+		return value != nil && reflect.ValueOf(value).Kind() == reflect.Slice
+	case TypeObject:
+		return toBase(value) != nil
+	}
+	return false
+}
+
+// equal 比较两个值是否相等，数值按照大小比较。
+func equal(a, b any) bool {
+	if na, ok := toFloat(a); ok {
+		nb, ok := toFloat(b)
+		return ok && na == nb
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XPrefs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchema(t *testing.T) {
+	originalArgs := os.Args
+	defer func() {
+		os.Args = originalArgs
+		reset()
+		schemaMu.Lock()
+		delete(schemas, "Test/")
+		schemaMu.Unlock()
+	}()
+	os.Args = []string{"test"}
+
+	levels := []any{"Debug", "Info", "Warn"}
+	RegisterSchema("Test/", map[string]*Schema{
+		"Test/Name":  {Type: TypeString},
+		"Test/Count": {Type: TypeInteger, Range: []float64{1, 10}},
+		"Test/Ratio": {Type: TypeNumber, Range: []float64{0, 1}},
+		"Test/Debug": {Type: TypeBoolean},
+		"Test/Log": {Type: TypeObject, Fields: map[string]*Schema{
+			"Level": {Type: TypeString, Enum: levels},
+			"Color": {Type: TypeBoolean},
+		}},
+		"Test/Ports": {Type: TypeArray, Items: &Schema{Type: TypeInteger, Range: []float64{1, 65535}}},
+		"Test/Mode": {Type: TypeString, Check: func(value any) error {
+			if !strings.HasPrefix(value.(string), "m") {
+				return errors.New("mode must start with m")
+			}
+			return nil
+		}},
+		"Invalid/Key": {Type: TypeString},
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		pb := &prefsBase{}
+		assert.True(t, pb.parse([]byte(`{
+			"Test/Name": "App",
+			"Test/Count": "3",
+			"Test/Ratio": 0.5,
+			"Test/Debug": true,
+			"Test/Log": {"Level": "Info", "Color": false},
+			"Test/Ports": [80, 443],
+			"Test/Mode": "main",
+			"Other": "ignored"
+		}`)))
+		assert.NoError(t, Validate(pb), "符合规则的配置应当校验通过，未注册前缀的配置项不应当被校验")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		pb := &prefsBase{}
+		assert.True(t, pb.parse([]byte(`{
+			"Test/Name": 1,
+			"Test/Count": "many",
+			"Test/Ratio": 2,
+			"Test/Debug": "maybe",
+			"Test/Log": {"Level": "Warning", "Colour": true},
+			"Test/Ports": [80, 0],
+			"Test/Mode": "debug",
+			"Test/Nmae": "App"
+		}`)))
+		err := Validate(pb)
+		assert.Error(t, err)
+		for _, msg := range []string{
+			"Test/Name: expect string",
+			"Test/Count: expect integer",
+			"Test/Ratio: value 2 is out of range [0, 1]",
+			"Test/Debug: expect boolean",
+			"Test/Log.Level: value Warning is not one of [Debug Info Warn]",
+			"Test/Log.Colour: unknown key",
+			"Test/Ports[1]: value 0 is out of range [1, 65535]",
+			"Test/Mode: mode must start with m",
+			"Test/Nmae: unknown key",
+		} {
+			assert.Contains(t, err.Error(), msg, "应当报告所有无效的配置项")
+		}
+		assert.Len(t, strings.Split(err.Error(), "\n"), 9)
+		assert.NotContains(t, err.Error(), "Invalid/Key", "前缀不匹配的规则应当被忽略")
+
+		assert.Error(t, Validate(nil), "空的配置应当报告错误")
+	})
+
+	t.Run("Match", func(t *testing.T) {
+		assert.True(t, matchType(TypeInteger, 3))
+		assert.True(t, matchType(TypeInteger, float64(3)))
+		assert.True(t, matchType(TypeInteger, "3.5"), "可被 GetInt 读取的值应当通过校验")
+		assert.False(t, matchType(TypeInteger, "x"))
+		assert.False(t, matchType(TypeInteger, true))
+		assert.True(t, matchType(TypeNumber, "3.5"))
+		assert.False(t, matchType(TypeNumber, "x"))
+		assert.True(t, matchType(TypeBoolean, "yes"), "布尔类型应当支持可转换为布尔值的字符串")
+		assert.False(t, matchType(TypeBoolean, 2))
+		assert.True(t, matchType(TypeArray, []string{"a"}))
+		assert.False(t, matchType(TypeArray, nil))
+		assert.True(t, matchType(TypeObject, map[string]any{}))
+		assert.False(t, matchType("unknown", 1))
+		assert.True(t, equal(1, float64(1)), "数值应当按照大小比较")
+	})
+
+	t.Run("Reload", func(t *testing.T) {
+		assetFile := filepath.Join(t.TempDir(), "Preferences.json")
+		assert.NoError(t, writeFile(assetFile, []byte(`{"Prefs/Watch/Interval": 10, "Test/Count": 1}`)))
+		reset()
+		os.Args = []string{"test", "--Prefs@Asset=" + assetFile}
+		defer func() { os.Args = []string{"test"} }()
+		assert.Equal(t, 1, Asset().GetInt("Test/Count"))
+
+		assert.NoError(t, writeFile(assetFile, []byte(`{"Prefs/Watch/Interval": 10, "Test/Count": 100}`)))
+		time.Sleep(time.Millisecond * 100)
+		assert.Equal(t, 1, Asset().GetInt("Test/Count"), "校验失败的配置应当被拒绝并保留原有的配置")
+
+		assert.NoError(t, writeFile(assetFile, []byte(`{"Prefs/Watch/Interval": 10, "Test/Count": 5}`)))
+		assert.Eventually(t, func() bool { return Asset().GetInt("Test/Count") == 5 }, time.Second, time.Millisecond*10,
+			"校验通过的配置应当被重新读取")
+	})
+
+	t.Run("Prefs", func(t *testing.T) {
+		pb := &prefsBase{}
+		assert.True(t, pb.parse([]byte(`{"Prefs/Remote/Timeout": 0, "Prefs/Remote/Retry": 1, "Prefs/Watch/Intervall": 10}`)))
+		err := Validate(pb)
+		assert.ErrorContains(t, err, "Prefs/Remote/Timeout: value 0 is out of range", "内置的配置项应当被校验")
+		assert.ErrorContains(t, err, "Prefs/Watch/Intervall: unknown key", "拼写错误的内置配置项应当被报告")
+	})
+}
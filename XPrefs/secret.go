@@ -0,0 +1,206 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XPrefs
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const (
+	// secretPrefix 加密的配置项的前缀，格式为 ENC(<Base64 编码的密文>)。
+	secretPrefix = "ENC("
+	// secretSuffix 加密的配置项的后缀。
+	secretSuffix = ")"
+	// secretKeyEnv 指定密钥的环境变量，值为 Base64 编码的密钥。
+	secretKeyEnv = "XPREFS_KEY"
+	// secretKeyFileEnv 指定密钥文件的环境变量，文件内容为 Base64 编码的密钥。
+	secretKeyFileEnv = "XPREFS_KEY_FILE"
+)
+
+var (
+	// secretMu 用于保护密钥缓存的互斥锁。
+	secretMu sync.Mutex
+
+	// secretKey 缓存的密钥，仅缓存读取成功的密钥。
+	secretKey []byte
+)
+
+// GenerateKey 生成随机的 AES-256 密钥，返回 Base64 编码的字符串。
+// 密钥可通过 XPREFS_KEY 环境变量、XPREFS_KEY_FILE 环境变量指定的文件、--Prefs@Key 或 --Prefs@KeyFile 参数提供。
+func GenerateKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// Encrypt 使用 AES-GCM 加密配置项的值，返回 ENC(...) 格式的字符串，可直接写入配置文件。
+// key 为 Base64 编码的 16、24 或 32 字节的密钥，未指定时使用环境变量、密钥文件或命令行参数提供的密钥。
+func Encrypt(plain string, key ...string) (string, error) {
+	block, err := secretCipher(key...)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, block.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	data := block.Seal(nonce, nonce, []byte(plain), nil)
+	return secretPrefix + base64.StdEncoding.EncodeToString(data) + secretSuffix, nil
+}
+
+// Decrypt 解密 ENC(...) 格式的配置项的值，非加密的值原样返回。
+// key 的规则与 Encrypt 一致，密钥错误或密文被篡改时返回错误。
+func Decrypt(value string, key ...string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	block, err := secretCipher(key...)
+	if err != nil {
+		return "", err
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(strings.TrimPrefix(value, secretPrefix), secretSuffix))
+	if err != nil {
+		return "", fmt.Errorf("invalid encrypted value: %v", err)
+	}
+	if len(data) < block.NonceSize() {
+		return "", errors.New("invalid encrypted value: data is too short")
+	}
+	plain, err := block.Open(nil, data[:block.NonceSize()], data[block.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("decrypt value error: %v", err)
+	}
+	return string(plain), nil
+}
+
+// IsEncrypted 检查配置项的值是否为 ENC(...) 格式的加密的值。
+func IsEncrypted(value any) bool {
+	s, ok := value.(string)
+	return ok && strings.HasPrefix(s, secretPrefix) && strings.HasSuffix(s, secretSuffix)
+}
+
+// secretCipher 根据指定或配置的密钥创建 AES-GCM 实例。
+func secretCipher(key ...string) (cipher.AEAD, error) {
+	var raw []byte
+	var err error
+	if len(key) > 0 && key[0] != "" {
+		raw, err = parseKey(key[0])
+	} else {
+		raw, err = loadKey()
+	}
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret key: %v", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// parseKey 解析 Base64 编码的密钥，密钥的长度须为 16、24 或 32 字节。
+func parseKey(key string) ([]byte, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(key))
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret key: %v", err)
+	}
+	switch len(raw) {
+	case 16, 24, 32:
+		return raw, nil
+	}
+	return nil, fmt.Errorf("invalid secret key: length of %v bytes, expect 16, 24 or 32", len(raw))
+}
+
+// loadKey 读取并缓存配置的密钥。
+// 依次读取 --Prefs@Key 参数、--Prefs@KeyFile 参数指定的文件、XPREFS_KEY 环境变量及 XPREFS_KEY_FILE 环境变量指定的文件，命令行参数为初始化时读取的参数。
+// 仅缓存读取成功的密钥，读取失败时下次解密重新读取，以支持初始化后挂载或写入的密钥文件。
+func loadKey() ([]byte, error) {
+	secretMu.Lock()
+	cached := secretKey
+	secretMu.Unlock()
+	if cached != nil {
+		return cached, nil
+	}
+
+	args := initArgs()
+	fromFile := func(file string) (string, error) {
+		data, err := readFile(file)
+		if err != nil {
+			return "", fmt.Errorf("read secret key file %v error: %v", file, err)
+		}
+		return string(data), nil
+	}
+	var key string
+	var err error
+	if v := args["Prefs@Key"]; v != "" {
+		key = v
+	} else if v := args["Prefs@KeyFile"]; v != "" {
+		key, err = fromFile(v)
+	} else if v := os.Getenv(secretKeyEnv); v != "" {
+		key = v
+	} else if v := os.Getenv(secretKeyFileEnv); v != "" {
+		key, err = fromFile(v)
+	} else {
+		err = errors.New("secret key is not set")
+	}
+	if err != nil {
+		return nil, err
+	}
+	raw, err := parseKey(key)
+	if err != nil {
+		return nil, err
+	}
+	secretMu.Lock()
+	defer secretMu.Unlock()
+	secretKey = raw
+	return raw, nil
+}
+
+// resetKey 清除缓存的密钥，下次解密时重新读取。
+func resetKey() {
+	secretMu.Lock()
+	defer secretMu.Unlock()
+	secretKey = nil
+}
+
+// reveal 解密加密的配置项，非加密的值原样返回。
+// 返回解密后的值、是否为加密的值及解密失败时的错误。
+func reveal(value any) (any, bool, error) {
+	if !IsEncrypted(value) {
+		return value, false, nil
+	}
+	plain, err := Decrypt(value.(string))
+	if err != nil {
+		return nil, true, err
+	}
+	return plain, true, nil
+}
+
+// sealed 包装类型转换函数，转换前解密加密的值。
+// 解密后的值转换失败时返回不包含明文的错误，避免明文出现在错误信息及日志中。
+func sealed[T any](coerce func(any) (T, error)) func(any) (T, error) {
+	return func(value any) (T, error) {
+		var zero T
+		plain, secret, err := reveal(value)
+		if err != nil {
+			return zero, err
+		}
+		ret, err := coerce(plain)
+		if err != nil && secret {
+			return zero, fmt.Errorf("can not convert decrypted value to %v", reflect.TypeFor[T]())
+		}
+		return ret, err
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XPrefs
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecret(t *testing.T) {
+	originalArgs := os.Args
+	defer func() {
+		os.Args = originalArgs
+		reset()
+	}()
+	os.Args = []string{"test"}
+	t.Setenv(secretKeyEnv, "")
+	t.Setenv(secretKeyFileEnv, "")
+
+	key, err := GenerateKey()
+	assert.NoError(t, err)
+
+	t.Run("Cipher", func(t *testing.T) {
+		value, err := Encrypt("p@ss", key)
+		assert.NoError(t, err)
+		assert.True(t, IsEncrypted(value), "加密的值应当为 ENC(...) 格式")
+		assert.NotContains(t, value, "p@ss")
+
+		other, _ := Encrypt("p@ss", key)
+		assert.NotEqual(t, value, other, "每次加密应当使用随机的 nonce")
+
+		plain, err := Decrypt(value, key)
+		assert.NoError(t, err)
+		assert.Equal(t, "p@ss", plain)
+
+		plain, err = Decrypt("plain", key)
+		assert.NoError(t, err)
+		assert.Equal(t, "plain", plain, "非加密的值应当原样返回")
+
+		otherKey, _ := GenerateKey()
+		_, err = Decrypt(value, otherKey)
+		assert.Error(t, err, "密钥错误时应当返回错误")
+		data, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(strings.TrimPrefix(value, secretPrefix), secretSuffix))
+		assert.NoError(t, err)
+		data[len(data)-1] ^= 0xff
+		_, err = Decrypt(secretPrefix+base64.StdEncoding.EncodeToString(data)+secretSuffix, key)
+		assert.Error(t, err, "密文被篡改时应当返回错误")
+		_, err = Encrypt("p@ss", "c2hvcnQ=")
+		assert.ErrorContains(t, err, "expect 16, 24 or 32", "长度无效的密钥应当返回错误")
+
+		resetKey()
+		_, err = Encrypt("p@ss")
+		assert.ErrorContains(t, err, "secret key is not set", "未配置密钥时应当返回错误")
+	})
+
+	t.Run("Key", func(t *testing.T) {
+		value, _ := Encrypt("p@ss", key)
+		keyFile := filepath.Join(t.TempDir(), "prefs.key")
+		assert.NoError(t, writeFile(keyFile, []byte(key+"\n")))
+
+		for _, c := range []struct {
+			name string
+			args []string
+			envs map[string]string
+		}{
+			{"Arg", []string{"--Prefs@Key=" + key}, nil},
+			{"ArgFile", []string{"--Prefs@KeyFile=" + keyFile}, nil},
+			{"Env", nil, map[string]string{secretKeyEnv: key}},
+			{"EnvFile", nil, map[string]string{secretKeyFileEnv: keyFile}},
+		} {
+			t.Run(c.name, func(t *testing.T) {
+				os.Args = append([]string{"test"}, c.args...)
+				defer func() { os.Args = []string{"test"} }()
+				for k, v := range c.envs {
+					t.Setenv(k, v)
+				}
+				reset()
+				plain, err := Decrypt(value)
+				assert.NoError(t, err, "应当读取配置的密钥")
+				assert.Equal(t, "p@ss", plain)
+			})
+		}
+
+		lateFile := filepath.Join(t.TempDir(), "late.key")
+		os.Args = []string{"test", "--Prefs@KeyFile=" + lateFile}
+		reset()
+		_, err := Decrypt(value)
+		assert.ErrorContains(t, err, "read secret key file", "密钥文件不存在时应当返回错误")
+		assert.NoError(t, writeFile(lateFile, []byte(key)))
+		plain, err := Decrypt(value)
+		assert.NoError(t, err, "读取失败后应当重新读取后续写入的密钥文件")
+		assert.Equal(t, "p@ss", plain)
+
+		os.Args = []string{"test", "--Prefs@Key=" + key}
+		reset()
+		Asset()
+		os.Args = []string{"test"}
+		resetKey()
+		_, err = Decrypt(value)
+		assert.NoError(t, err, "应当使用初始化时读取的命令行参数")
+		reset()
+	})
+
+	t.Run("Getters", func(t *testing.T) {
+		password, _ := Encrypt("p@ss", key)
+		port, _ := Encrypt("8080", key)
+		tags, _ := Encrypt(`["a", "b"]`, key)
+		invalid, _ := Encrypt("not-a-number", key)
+		tag, _ := Encrypt("c", key)
+
+		assetFile := filepath.Join(t.TempDir(), "Preferences.json")
+		assert.NoError(t, writeFile(assetFile, []byte(`{
+			"DB": {"Password": "`+password+`", "Port": "`+port+`"},
+			"Tags": "`+tags+`",
+			"Items": ["a", "`+tag+`"],
+			"Invalid": "`+invalid+`",
+			"Url": "db://${Prefs.DB.Password}"
+		}`)))
+		reset()
+		os.Args = []string{"test", "--Prefs@Asset=" + assetFile, "--Prefs@Key=" + key}
+		defer func() { os.Args = []string{"test"} }()
+
+		db := Asset().Get("DB").(IBase)
+		assert.Equal(t, "p@ss", db.GetString("Password"), "Get 系列函数应当透明地解密")
+		assert.Equal(t, 8080, db.GetInt("Port"), "解密后的值应当按照类型转换的规则转换")
+		assert.Equal(t, "p@ss", GetPathAs[string](Asset(), "DB.Password"))
+		assert.Equal(t, []string{"a", "b"}, Asset().GetStrings("Tags"), "加密的 JSON 数组应当被解密")
+		assert.Equal(t, []string{"a", "c"}, Asset().GetStrings("Items"), "数组中加密的元素应当被解密")
+		assert.Equal(t, password, db.Get("Password"), "Get 应当返回加密的原始值")
+
+		_, err := Asset().TryInt("Invalid")
+		assert.Error(t, err)
+		assert.NotContains(t, err.Error(), "not-a-number", "转换失败的错误信息不应当包含明文")
+
+		var cfg struct {
+			DB struct {
+				Password string `prefs:"Password"`
+				Port     int    `prefs:"Port"`
+			} `prefs:"DB"`
+			Invalid int `prefs:"Invalid"`
+		}
+		err = Bind(Asset(), &cfg)
+		assert.Equal(t, "p@ss", cfg.DB.Password, "绑定结构体时应当解密")
+		assert.Equal(t, 8080, cfg.DB.Port)
+		assert.Error(t, err)
+		assert.NotContains(t, err.Error(), "not-a-number", "绑定失败的错误信息不应当包含明文")
+
+		assert.NotContains(t, Asset().Json(), "p@ss", "Json 不应当包含明文")
+		assert.Contains(t, Asset().Json(), password)
+		assert.False(t, strings.Contains(Asset().GetString("Url"), "p@ss"), "变量引用不应当解密")
+		assert.NoError(t, Validate(Asset()))
+
+		os.Args = []string{"test", "--Prefs@Asset=" + assetFile}
+		reset()
+		assert.Equal(t, "default", db.GetString("Password", "default"), "未配置密钥时应当返回默认值")
+		_, err = db.TryString("Password")
+		assert.ErrorContains(t, err, "secret key is not set")
+	})
+}
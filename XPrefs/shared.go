@@ -9,7 +9,9 @@ import (
 	"os"
 	"os/signal"
 	"sync"
+	"sync/atomic"
 	"syscall"
+	"time"
 
 	"github.com/illumitacit/gostd/quit"
 )
@@ -32,6 +34,9 @@ var (
 
 	// local 全局本地配置实例。
 	local *prefsLocal
+
+	// initOverrides 初始化时读取的覆盖配置项的命令行参数及环境变量。
+	initOverrides atomic.Pointer[overrides]
 )
 
 // Asset 获取资产配置实例。
@@ -48,6 +53,18 @@ func Local() *prefsLocal {
 	return local
 }
 
+// initArgs 返回初始化时读取的命令行参数，尚未初始化时先初始化配置系统。
+func initArgs() map[string]string {
+	if ovr := initOverrides.Load(); ovr != nil {
+		return ovr.args
+	}
+	Asset()
+	if ovr := initOverrides.Load(); ovr != nil {
+		return ovr.args
+	}
+	return nil
+}
+
 // reset 重置初始化状态。
 // 仅用于测试目的，重置配置系统的初始化状态。
 func reset() {
@@ -67,23 +84,30 @@ func reset() {
 	// 重置同步对象
 	initWait = sync.WaitGroup{}
 	initOnce = sync.Once{}
+	remoteOnce = sync.Once{}
+	resetKey()
+	initOverrides.Store(nil)
 
 	// 重置配置实例
 	asset = nil
 	local = nil
+	remote.Store(nil)
 }
 
 // setup 初始化配置系统。
-// 读取配置文件，设置信号处理，启用自动保存功能。
+// 读取并校验配置文件，设置信号处理，启用自动保存及配置文件的变更检测功能。
 func setup() {
 	initMu.Lock()
 	defer initMu.Unlock()
 
-	args := parseArgs()
-	assetFileArg := args["Prefs@Asset"]
-	localFileArg := args["Prefs@Local"]
+	// 命令行参数及环境变量仅在初始化时读取，重新读取配置时复用
+	ovr := readOverrides()
+	initOverrides.Store(ovr)
+	assetFileArg := ovr.args["Prefs@Asset"]
+	localFileArg := ovr.args["Prefs@Local"]
 
 	asset = &prefsAsset{}
+	asset.ovr = ovr
 	if assetFileArg != "" {
 		asset.read(assetFileArg)
 	} else {
@@ -91,12 +115,16 @@ func setup() {
 	}
 
 	local = &prefsLocal{}
+	local.ovr = ovr
 	if localFileArg != "" {
 		local.read(localFileArg)
 	} else {
 		local.read()
 	}
 
+	report("Asset", validate(asset))
+	report("Local", validate(local))
+
 	if initSig != nil {
 		signal.Stop(initSig)
 		close(initSig)
@@ -104,6 +132,14 @@ func setup() {
 	initSig = make(chan os.Signal, 1)
 	signal.Notify(initSig, syscall.SIGTERM, syscall.SIGINT)
 
+	var tick <-chan time.Time
+	var ticker *time.Ticker
+	if interval := asset.GetInt(prefsWatchInterval, prefsWatchIntervalDefault); interval > 0 {
+		ticker = time.NewTicker(time.Duration(interval) * time.Millisecond)
+		tick = ticker.C
+	}
+	pa, pl, sigs := asset, local, initSig
+
 	initWait.Add(1)
 	quit.GetWaiter().Add(1)
 	wg := sync.WaitGroup{}
@@ -112,13 +148,19 @@ func setup() {
 	go func() {
 		wg.Done()
 		defer func() {
+			if ticker != nil {
+				ticker.Stop()
+			}
 			Local().Save()
 			quit.GetWaiter().Done()
 			initWait.Done()
 		}()
 		for {
 			select {
-			case sig, ok := <-initSig:
+			case <-tick:
+				pa.reload()
+				pl.reload()
+			case sig, ok := <-sigs:
 				if ok {
 					fmt.Printf("XPrefs.Listen: receive signal of %v.\n", sig.String())
 				} else {
@@ -139,11 +181,11 @@ func setup() {
 func New() IBase { return new(prefsBase) }
 
 // HasKey 检查配置项是否存在。
-// 输入键名和可选的配置源列表，如果未提供配置源则在资产配置中查找。
+// 输入键名和可选的配置源列表，如果未提供配置源则依次在远程配置及资产配置中查找。
 // 返回 true 表示配置项存在，false 表示不存在。
 func HasKey(key string, sources ...IBase) bool {
 	if len(sources) == 0 {
-		return Asset().Has(key)
+		return sourceOf(key, nil).Has(key)
 	}
 
 	for _, source := range sources {
@@ -160,22 +202,27 @@ func HasKey(key string, sources ...IBase) bool {
 // 按优先级从配置源中查找值，如果未找到则返回默认值。
 func Get(key string, defvalAndSources ...any) any {
 	if len(defvalAndSources) == 0 {
-		return Asset().Get(key)
+		return sourceOf(key, nil).Get(key)
 	}
 
-	defval := defvalAndSources[0]
-	for _, source := range defvalAndSources[1:] {
-		if source, ok := source.(IBase); ok {
-			if source.Has(key) {
-				return source.Get(key)
-			}
-		}
+	defval, sources := splitArgs(defvalAndSources)
+	if source := sourceOf(key, sources); source.Has(key) {
+		return source.Get(key)
 	}
 
-	if Asset().Has(key) {
-		return Asset().Get(key)
-	}
+	return defval
+}
 
+// GetPath 获取配置路径对应的配置项的值。
+// 输入配置路径和可变参数列表，第一个参数为默认值，后续参数为配置源列表。
+// 路径使用 . 分隔各级配置，键名中的 . 和 \ 需转义，如 Log/File.Level，按优先级从配置源中查找值，如果未找到则返回默认值。
+func GetPath(path string, defvalAndSources ...any) any {
+	defval, sources := splitArgs(defvalAndSources)
+	for _, source := range chain(sources) {
+		if source != nil && source.HasPath(path) {
+			return source.GetPath(path)
+		}
+	}
 	return defval
 }
 
@@ -184,20 +231,12 @@ func Get(key string, defvalAndSources ...any) any {
 // 按优先级从配置源中查找值数组，如果未找到则返回默认值数组。
 func Gets(key string, defvalAndSources ...any) []any {
 	if len(defvalAndSources) == 0 {
-		return Asset().Gets(key)
+		return sourceOf(key, nil).Gets(key)
 	}
 
-	defval := defvalAndSources[0]
-	for _, source := range defvalAndSources[1:] {
-		if source, ok := source.(IBase); ok {
-			if source.Has(key) {
-				return source.Gets(key)
-			}
-		}
-	}
-
-	if Asset().Has(key) {
-		return Asset().Gets(key)
+	defval, sources := splitArgs(defvalAndSources)
+	if source := sourceOf(key, sources); source.Has(key) {
+		return source.Gets(key)
 	}
 
 	if val, ok := defval.([]any); ok {
@@ -211,222 +250,200 @@ func Gets(key string, defvalAndSources ...any) []any {
 // 输入键名和可变参数列表，第一个参数为默认值，后续参数为配置源列表。
 // 按优先级从配置源中查找并转换为整数，如果未找到或转换失败则返回默认值。
 func GetInt(key string, defvalAndSources ...any) int {
-	if len(defvalAndSources) == 0 {
-		return Asset().GetInt(key)
-	}
-
-	defval := defvalAndSources[0]
-	for _, source := range defvalAndSources[1:] {
-		if source, ok := source.(IBase); ok {
-			if source.Has(key) {
-				return source.GetInt(key)
-			}
-		}
-	}
-
-	if Asset().Has(key) {
-		return Asset().GetInt(key)
+	defval, sources := splitArgs(defvalAndSources)
+	if val, err := TryInt(key, sources...); err == nil {
+		return val
 	}
-
 	if val, ok := defval.(int); ok {
 		return val
 	}
-
 	return 0
 }
 
+// TryInt 获取配置项的整数值，类型转换的规则与 GetInt 一致。
+// 按优先级从配置源中查找，如果均未找到则依次在远程配置及资产配置中查找，首个包含该配置项的配置源决定返回的结果。
+// 配置项不存在时返回包装了 ErrNotFound 的错误，类型转换失败时返回转换的错误。
+func TryInt(key string, sources ...IBase) (int, error) {
+	return sourceOf(key, sources).TryInt(key)
+}
+
 // GetInts 获取配置项的整数数组。
 // 输入键名和可变参数列表，第一个参数为默认值数组，后续参数为配置源列表。
 // 按优先级从配置源中查找并转换为整数数组，如果未找到或转换失败则返回默认值数组。
 func GetInts(key string, defvalAndSources ...any) []int {
-	if len(defvalAndSources) == 0 {
-		return Asset().GetInts(key)
-	}
-
-	defval := defvalAndSources[0]
-	for _, source := range defvalAndSources[1:] {
-		if source, ok := source.(IBase); ok {
-			if source.Has(key) {
-				return source.GetInts(key)
-			}
-		}
-	}
-
-	if Asset().Has(key) {
-		return Asset().GetInts(key)
+	defval, sources := splitArgs(defvalAndSources)
+	if val, err := TryInts(key, sources...); err == nil {
+		return val
 	}
-
 	if val, ok := defval.([]int); ok {
 		return val
 	}
-
 	return nil
 }
 
+// TryInts 获取配置项的整数数组，类型转换的规则与 GetInts 一致。
+// 按优先级从配置源中查找，如果均未找到则依次在远程配置及资产配置中查找，首个包含该配置项的配置源决定返回的结果。
+// 配置项不存在时返回包装了 ErrNotFound 的错误，类型转换失败时返回转换的错误。
+func TryInts(key string, sources ...IBase) ([]int, error) {
+	return sourceOf(key, sources).TryInts(key)
+}
+
 // GetFloat 获取配置项的浮点数值。
 // 输入键名和可变参数列表，第一个参数为默认值，后续参数为配置源列表。
 // 按优先级从配置源中查找并转换为浮点数，如果未找到或转换失败则返回默认值。
 func GetFloat(key string, defvalAndSources ...any) float32 {
-	if len(defvalAndSources) == 0 {
-		return Asset().GetFloat(key)
-	}
-
-	defval := defvalAndSources[0]
-	for _, source := range defvalAndSources[1:] {
-		if source, ok := source.(IBase); ok {
-			if source.Has(key) {
-				return source.GetFloat(key)
-			}
-		}
-	}
-
-	if Asset().Has(key) {
-		return Asset().GetFloat(key)
+	defval, sources := splitArgs(defvalAndSources)
+	if val, err := TryFloat(key, sources...); err == nil {
+		return val
 	}
-
 	if val, ok := defval.(float32); ok {
 		return val
 	}
-
 	return 0
 }
 
+// TryFloat 获取配置项的浮点数值，类型转换的规则与 GetFloat 一致。
+// 按优先级从配置源中查找，如果均未找到则依次在远程配置及资产配置中查找，首个包含该配置项的配置源决定返回的结果。
+// 配置项不存在时返回包装了 ErrNotFound 的错误，类型转换失败时返回转换的错误。
+func TryFloat(key string, sources ...IBase) (float32, error) {
+	return sourceOf(key, sources).TryFloat(key)
+}
+
 // GetFloats 获取配置项的浮点数数组。
 // 输入键名和可变参数列表，第一个参数为默认值数组，后续参数为配置源列表。
 // 按优先级从配置源中查找并转换为浮点数数组，如果未找到或转换失败则返回默认值数组。
 func GetFloats(key string, defvalAndSources ...any) []float32 {
-	if len(defvalAndSources) == 0 {
-		return Asset().GetFloats(key)
-	}
-
-	defval := defvalAndSources[0]
-	for _, source := range defvalAndSources[1:] {
-		if source, ok := source.(IBase); ok {
-			if source.Has(key) {
-				return source.GetFloats(key)
-			}
-		}
-	}
-
-	if Asset().Has(key) {
-		return Asset().GetFloats(key)
+	defval, sources := splitArgs(defvalAndSources)
+	if val, err := TryFloats(key, sources...); err == nil {
+		return val
 	}
-
 	if val, ok := defval.([]float32); ok {
 		return val
 	}
-
 	return nil
 }
 
+// TryFloats 获取配置项的浮点数数组，类型转换的规则与 GetFloats 一致。
+// 按优先级从配置源中查找，如果均未找到则依次在远程配置及资产配置中查找，首个包含该配置项的配置源决定返回的结果。
+// 配置项不存在时返回包装了 ErrNotFound 的错误，类型转换失败时返回转换的错误。
+func TryFloats(key string, sources ...IBase) ([]float32, error) {
+	return sourceOf(key, sources).TryFloats(key)
+}
+
 // GetBool 获取配置项的布尔值。
 // 输入键名和可变参数列表，第一个参数为默认值，后续参数为配置源列表。
 // 按优先级从配置源中查找并转换为布尔值，如果未找到或转换失败则返回默认值。
 func GetBool(key string, defvalAndSources ...any) bool {
-	if len(defvalAndSources) == 0 {
-		return Asset().GetBool(key)
-	}
-
-	defval := defvalAndSources[0]
-	for _, source := range defvalAndSources[1:] {
-		if source, ok := source.(IBase); ok {
-			if source.Has(key) {
-				return source.GetBool(key)
-			}
-		}
-	}
-
-	if Asset().Has(key) {
-		return Asset().GetBool(key)
+	defval, sources := splitArgs(defvalAndSources)
+	if val, err := TryBool(key, sources...); err == nil {
+		return val
 	}
-
 	if val, ok := defval.(bool); ok {
 		return val
 	}
 	return false
 }
 
+// TryBool 获取配置项的布尔值，类型转换的规则与 GetBool 一致。
+// 按优先级从配置源中查找，如果均未找到则依次在远程配置及资产配置中查找，首个包含该配置项的配置源决定返回的结果。
+// 配置项不存在时返回包装了 ErrNotFound 的错误，类型转换失败时返回转换的错误。
+func TryBool(key string, sources ...IBase) (bool, error) {
+	return sourceOf(key, sources).TryBool(key)
+}
+
 // GetBools 获取配置项的布尔值数组。
 // 输入键名和可变参数列表，第一个参数为默认值数组，后续参数为配置源列表。
 // 按优先级从配置源中查找并转换为布尔值数组，如果未找到或转换失败则返回默认值数组。
 func GetBools(key string, defvalAndSources ...any) []bool {
-	if len(defvalAndSources) == 0 {
-		return Asset().GetBools(key)
-	}
-
-	defval := defvalAndSources[0]
-	for _, source := range defvalAndSources[1:] {
-		if source, ok := source.(IBase); ok {
-			if source.Has(key) {
-				return source.GetBools(key)
-			}
-		}
-	}
-
-	if Asset().Has(key) {
-		return Asset().GetBools(key)
+	defval, sources := splitArgs(defvalAndSources)
+	if val, err := TryBools(key, sources...); err == nil {
+		return val
 	}
-
 	if val, ok := defval.([]bool); ok {
 		return val
 	}
-
 	return nil
 }
 
+// TryBools 获取配置项的布尔值数组，类型转换的规则与 GetBools 一致。
+// 按优先级从配置源中查找，如果均未找到则依次在远程配置及资产配置中查找，首个包含该配置项的配置源决定返回的结果。
+// 配置项不存在时返回包装了 ErrNotFound 的错误，类型转换失败时返回转换的错误。
+func TryBools(key string, sources ...IBase) ([]bool, error) {
+	return sourceOf(key, sources).TryBools(key)
+}
+
 // GetString 获取配置项的字符串值。
 // 输入键名和可变参数列表，第一个参数为默认值，后续参数为配置源列表。
 // 按优先级从配置源中查找并转换为字符串，如果未找到或转换失败则返回默认值。
 func GetString(key string, defvalAndSources ...any) string {
-	if len(defvalAndSources) == 0 {
-		return Asset().GetString(key)
-	}
-
-	defval := defvalAndSources[0]
-	for _, source := range defvalAndSources[1:] {
-		if source, ok := source.(IBase); ok {
-			if source.Has(key) {
-				return source.GetString(key)
-			}
-		}
-	}
-
-	if Asset().Has(key) {
-		return Asset().GetString(key)
+	defval, sources := splitArgs(defvalAndSources)
+	if val, err := TryString(key, sources...); err == nil {
+		return val
 	}
-
 	if val, ok := defval.(string); ok {
 		return val
 	}
-
 	return ""
 }
 
+// TryString 获取配置项的字符串值，类型转换的规则与 GetString 一致。
+// 按优先级从配置源中查找，如果均未找到则依次在远程配置及资产配置中查找，首个包含该配置项的配置源决定返回的结果。
+// 配置项不存在时返回包装了 ErrNotFound 的错误，类型转换失败时返回转换的错误。
+func TryString(key string, sources ...IBase) (string, error) {
+	return sourceOf(key, sources).TryString(key)
+}
+
 // GetStrings 获取配置项的字符串数组。
 // 输入键名和可变参数列表，第一个参数为默认值数组，后续参数为配置源列表。
 // 按优先级从配置源中查找并转换为字符串数组，如果未找到或转换失败则返回默认值数组。
 func GetStrings(key string, defvalAndSources ...any) []string {
-	if len(defvalAndSources) == 0 {
-		return Asset().GetStrings(key)
+	defval, sources := splitArgs(defvalAndSources)
+	if val, err := TryStrings(key, sources...); err == nil {
+		return val
 	}
+	if val, ok := defval.([]string); ok {
+		return val
+	}
+	return nil
+}
 
-	defval := defvalAndSources[0]
+// TryStrings 获取配置项的字符串数组，类型转换的规则与 GetStrings 一致。
+// 按优先级从配置源中查找，如果均未找到则依次在远程配置及资产配置中查找，首个包含该配置项的配置源决定返回的结果。
+// 配置项不存在时返回包装了 ErrNotFound 的错误，类型转换失败时返回转换的错误。
+func TryStrings(key string, sources ...IBase) ([]string, error) {
+	return sourceOf(key, sources).TryStrings(key)
+}
 
+// splitArgs 将 Get 系列函数的可变参数拆分为默认值及配置源列表，非 IBase 类型的配置源将被忽略。
+func splitArgs(defvalAndSources []any) (any, []IBase) {
+	if len(defvalAndSources) == 0 {
+		return nil, nil
+	}
+	sources := make([]IBase, 0, len(defvalAndSources)-1)
 	for _, source := range defvalAndSources[1:] {
 		if source, ok := source.(IBase); ok {
-			if source.Has(key) {
-				return source.GetStrings(key)
-			}
+			sources = append(sources, source)
 		}
 	}
+	return defvalAndSources[0], sources
+}
 
-	if Asset().Has(key) {
-		return Asset().GetStrings(key)
+// sourceOf 按优先级返回首个包含配置项的配置源，依次查找指定的配置源、远程配置及资产配置，均不包含时返回资产配置。
+func sourceOf(key string, sources []IBase) IBase {
+	for _, source := range chain(sources) {
+		if source != nil && source.Has(key) {
+			return source
+		}
 	}
+	return Asset()
+}
 
-	if val, ok := defval.([]string); ok {
-		return val
+// chain 返回按优先级排列的配置源，依次为指定的配置源、远程配置及资产配置。
+// 远程配置仅在通过 Remote 加载后参与查找，避免读取配置时隐式地发起网络请求。
+func chain(sources []IBase) []IBase {
+	ret := sources[:len(sources):len(sources)]
+	if pr := remote.Load(); pr != nil {
+		ret = append(ret, pr)
 	}
-
-	return nil
+	return append(ret, Asset())
 }
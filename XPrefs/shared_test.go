@@ -15,6 +15,20 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// TestMain 在临时目录中运行测试，避免默认路径的本地配置写入至代码目录。
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "XPrefs")
+	if err != nil {
+		panic(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		panic(err)
+	}
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
 func TestBasic(t *testing.T) {
 	pf := New()
 
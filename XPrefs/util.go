@@ -5,11 +5,32 @@
 package XPrefs
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 )
 
+const (
+	// envPrefixDefault 用于覆盖配置项的环境变量的默认前缀，可通过命令行参数 --Prefs@Env 指定。
+	envPrefixDefault = "XPREFS"
+	// envSeparatorDefault 环境变量中键名层级的默认分隔符，可通过命令行参数 --Prefs@EnvSeparator 指定。
+	envSeparatorDefault = "__"
+)
+
+// envScopes 支持单独覆盖的配置源名称。
+var envScopes = []string{"Asset", "Local", "Remote"}
+
+// envValue 定义了覆盖配置项的环境变量。
+type envValue struct {
+	name  string // 环境变量的名称
+	value any    // 按照 JSON 字面量解析的值
+}
+
 // parseArgs 解析命令行参数。
 // 将命令行参数解析为键值对形式的映射。支持两种格式：
 // 1. --key=value 格式
@@ -37,6 +58,151 @@ func parseArgs() map[string]string {
 	return argsMap
 }
 
+// overrides 定义了覆盖配置项的命令行参数及环境变量。
+// 全局的配置实例在初始化时读取，重新读取配置时复用，避免监听协程读取 os.Args 及环境变量。
+type overrides struct {
+	args map[string]string              // 命令行参数
+	envs map[string]map[string]envValue // 配置源名称到环境变量的映射，名称为空时作用于所有配置源
+}
+
+// readOverrides 读取当前的命令行参数及所有配置源的用于覆盖配置项的环境变量。
+func readOverrides() *overrides {
+	args := parseArgs()
+	envs := make(map[string]map[string]envValue, len(envScopes)+1)
+	for _, scope := range append([]string{""}, envScopes...) {
+		envs[scope] = parseEnvs(args, scope)
+	}
+	return &overrides{args: args, envs: envs}
+}
+
+// parseEnvs 解析用于覆盖配置项的环境变量。
+// 环境变量的格式为 <前缀><分隔符><键名>，如 XPREFS__Loom__Count=4，作用于所有配置源；
+// 指定配置源时格式为 <前缀>_<配置源><分隔符><键名>，如 XPREFS_ASSET__Loom__Count=4。
+// 键名中的分隔符转换为 /，. 表示多级配置；前缀为空时不读取环境变量。
+// 值按照 JSON 字面量解析，如数值、布尔值、数组及对象，解析失败时作为字符串。
+// args 为命令行参数，用于读取 --Prefs@Env 及 --Prefs@EnvSeparator；返回键名到环境变量的映射。
+func parseEnvs(args map[string]string, scope string) map[string]envValue {
+	prefix, ok := args["Prefs@Env"]
+	if !ok {
+		prefix = envPrefixDefault
+	}
+	sep := args["Prefs@EnvSeparator"]
+	if sep == "" {
+		sep = envSeparatorDefault
+	}
+	if prefix == "" {
+		return nil
+	}
+
+	head := prefix + sep
+	if scope != "" {
+		head = prefix + "_" + strings.ToUpper(scope) + sep
+	}
+	envs := make(map[string]envValue)
+	for _, env := range os.Environ() {
+		name, value, _ := strings.Cut(env, "=")
+		if !strings.HasPrefix(name, head) {
+			continue
+		}
+		if scope == "" && scoped(name, prefix, sep) {
+			continue
+		}
+		key := strings.ReplaceAll(strings.TrimPrefix(name, head), sep, "/")
+		if key == "" {
+			continue
+		}
+		envs[key] = envValue{name: name, value: parseLiteral(value)}
+	}
+	return envs
+}
+
+// scoped 检查环境变量是否为指定配置源的覆盖，用于分隔符与配置源前缀重叠时的区分。
+func scoped(name, prefix, sep string) bool {
+	for _, scope := range envScopes {
+		if strings.HasPrefix(name, prefix+"_"+strings.ToUpper(scope)+sep) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseLiteral 将字符串按照 JSON 字面量解析，解析失败或为 null 时返回原字符串。
+// 数值仅在可无损还原为原字面量时转换为 int64 或 float64，否则保留原字面量，如 1.10 及超出 int64 范围的整数，参见 parseNumber。
+func parseLiteral(value string) any {
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil || v == nil {
+		return value
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return value
+	}
+	return parseNumber(v)
+}
+
+// parseNumber 递归地将 json.Number 转换为 int64 或 float64，无法无损还原为原字面量的数值保留为字符串。
+func parseNumber(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		s := v.String()
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil && strconv.FormatInt(i, 10) == s {
+			return i
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil && strconv.FormatFloat(f, 'f', -1, 64) == s {
+			return f
+		}
+		return s
+	case []any:
+		for i := range v {
+			v[i] = parseNumber(v[i])
+		}
+	case map[string]any:
+		for key := range v {
+			v[key] = parseNumber(v[key])
+		}
+	}
+	return value
+}
+
+// override 使用环境变量及命令行参数覆盖配置项，命令行参数的优先级高于环境变量。
+// scope 为配置源的名称，如 Asset、Local 或 Remote，为空时应用作用于所有配置源的 XPREFS__Key 环境变量及 --Prefs.Key 参数。
+// 键名为配置路径，. 表示多级配置，不存在的上级配置将被创建，参见 SetPath；被覆盖的配置项记录环境变量或命令行参数作为来源，参见 Explain。
+// ovr 为覆盖配置项的命令行参数及环境变量，参见 readOverrides。
+func override(prefs IBase, scope string, ovr *overrides) {
+	name, argPrefix := "Base", "Prefs."
+	if scope != "" {
+		name, argPrefix = scope, "Prefs@"+scope+"."
+	}
+
+	envs := ovr.envs[scope]
+	keys := make([]string, 0, len(envs))
+	for key := range envs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	tr, _ := prefs.(tracer)
+	for _, key := range keys {
+		env := envs[key]
+		prefs.SetPath(key, env.value)
+		if tr != nil {
+			tr.trace(key, "env "+env.name)
+		}
+		fmt.Printf("XPrefs.%s.Parse: override %s = %v from env.\n", name, key, env.value)
+	}
+
+	for k, v := range ovr.args {
+		if strings.HasPrefix(k, argPrefix) {
+			key := strings.TrimPrefix(k, argPrefix)
+			prefs.SetPath(key, v)
+			if tr != nil {
+				tr.trace(key, "arg --"+k)
+			}
+			fmt.Printf("XPrefs.%s.Parse: override %s = %s\n", name, key, v)
+		}
+	}
+}
+
 // fileExists 检查文件是否存在。
 // 输入文件路径，如果文件存在且不是目录则返回 true，否则返回 false。
 // 用于在读取配置文件前进行检查。
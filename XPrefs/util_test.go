@@ -0,0 +1,107 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XPrefs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnv(t *testing.T) {
+	originalArgs := os.Args
+	defer func() {
+		os.Args = originalArgs
+		reset()
+	}()
+	os.Args = []string{"test"}
+
+	t.Run("Literal", func(t *testing.T) {
+		assert.Equal(t, int64(4), parseLiteral("4"))
+		assert.Equal(t, 1.5, parseLiteral("1.5"))
+		assert.Equal(t, "1.10", parseLiteral("1.10"), "无法无损还原的数值应当保留原字面量")
+		assert.Equal(t, int64(9007199254740993), parseLiteral("9007199254740993"), "大整数不应当损失精度")
+		assert.Equal(t, "99999999999999999999", parseLiteral("99999999999999999999"), "超出 int64 范围的整数应当保留原字面量")
+		assert.Equal(t, true, parseLiteral("true"))
+		assert.Equal(t, []any{"a", int64(1), "1.10"}, parseLiteral(`["a", 1, 1.10]`))
+		assert.Equal(t, map[string]any{"Count": int64(4)}, parseLiteral(`{"Count": 4}`))
+		assert.Equal(t, "1 2", parseLiteral("1 2"), "包含多余内容的值应当作为字符串")
+		assert.Equal(t, map[string]any{"Level": "Debug"}, parseLiteral(`{"Level": "Debug"}`))
+		assert.Equal(t, "4", parseLiteral(`"4"`), "带引号的值应当解析为字符串")
+		assert.Equal(t, "Debug", parseLiteral("Debug"), "无效的 JSON 字面量应当作为字符串")
+		assert.Equal(t, "null", parseLiteral("null"))
+		assert.Equal(t, "", parseLiteral(""))
+	})
+
+	t.Run("Base", func(t *testing.T) {
+		t.Setenv("XPREFS__Loom__Count", "4")
+		t.Setenv("XPREFS__Debug", "true")
+		t.Setenv("XPREFS__Tags", `["a", "b"]`)
+		t.Setenv("XPREFS__Log__File.Level", "Debug")
+		t.Setenv("XPREFS_ASSET__Scoped", "asset")
+		t.Setenv("OTHER__Key", "ignored")
+
+		pb := &prefsBase{}
+		assert.True(t, pb.parse([]byte(`{"Loom/Count": 1, "Log/File": {"Level": "Info", "Rotate": true}}`)))
+		assert.Equal(t, 4, pb.GetInt("Loom/Count"), "分隔符应当转换为 /")
+		assert.True(t, pb.GetBool("Debug"), "布尔值应当按照 JSON 字面量解析")
+		assert.Equal(t, []string{"a", "b"}, pb.GetStrings("Tags"), "数组应当按照 JSON 字面量解析")
+		file := pb.Get("Log/File").(IBase)
+		assert.Equal(t, "Debug", file.GetString("Level"), ". 应当表示多级配置")
+		assert.True(t, file.GetBool("Rotate"), "多级配置的其他配置项应当保留")
+		assert.False(t, pb.Has("Scoped"), "指定配置源的环境变量不应当作用于所有配置源")
+		assert.False(t, pb.Has("ASSET/Scoped"))
+		assert.False(t, pb.Has("Key"))
+	})
+
+	t.Run("Priority", func(t *testing.T) {
+		t.Setenv("XPREFS__Name", "env")
+		t.Setenv("XPREFS__Count", "2")
+		os.Args = []string{"test", "--Prefs.Name=arg"}
+		defer func() { os.Args = []string{"test"} }()
+
+		pb := &prefsBase{}
+		assert.True(t, pb.parse([]byte(`{"Name": "file", "Count": 1}`)))
+		assert.Equal(t, "arg", pb.GetString("Name"), "命令行参数的优先级应当高于环境变量")
+		assert.Equal(t, 2, pb.GetInt("Count"), "环境变量的优先级应当高于配置文件")
+	})
+
+	t.Run("Custom", func(t *testing.T) {
+		t.Setenv("MYAPP_Loom_Count", "8")
+		t.Setenv("XPREFS__Loom__Count", "4")
+		os.Args = []string{"test", "--Prefs@Env=MYAPP", "--Prefs@EnvSeparator=_"}
+		defer func() { os.Args = []string{"test"} }()
+
+		pb := &prefsBase{}
+		assert.True(t, pb.parse([]byte(`{}`)))
+		assert.Equal(t, 8, pb.GetInt("Loom/Count"), "应当支持自定义的前缀和分隔符")
+
+		os.Args = []string{"test", "--Prefs@Env="}
+		pb = &prefsBase{}
+		assert.True(t, pb.parse([]byte(`{}`)))
+		assert.False(t, pb.Has("Loom/Count"), "前缀为空时不应当读取环境变量")
+	})
+
+	t.Run("Sources", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		assetFile := filepath.Join(tmpDir, "Assets/Preferences.json")
+		localFile := filepath.Join(tmpDir, "Local/Preferences.json")
+		assert.NoError(t, writeFile(assetFile, []byte(`{"Name": "asset"}`)))
+		assert.NoError(t, writeFile(localFile, []byte(`{"Name": "local"}`)))
+		t.Setenv("XPREFS__Shared", "1")
+		t.Setenv("XPREFS_ASSET__Name", "envAsset")
+		t.Setenv("XPREFS_LOCAL__Name", "envLocal")
+
+		reset()
+		os.Args = []string{"test", "--Prefs@Asset=" + assetFile, "--Prefs@Local=" + localFile}
+		defer func() { os.Args = []string{"test"} }()
+		assert.Equal(t, "envAsset", Asset().GetString("Name"), "应当覆盖资产配置")
+		assert.Equal(t, "envLocal", Local().GetString("Name"), "应当覆盖本地配置")
+		assert.Equal(t, 1, Asset().GetInt("Shared"), "作用于所有配置源的环境变量应当覆盖资产配置")
+		assert.Equal(t, 1, Local().GetInt("Shared"), "作用于所有配置源的环境变量应当覆盖本地配置")
+	})
+}
@@ -0,0 +1,291 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XPrefs
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// prefsWatchInterval 配置文件变更检测的轮询间隔（毫秒）的配置键，小于等于 0 时不检测。
+	prefsWatchInterval = "Prefs/Watch/Interval"
+	// prefsWatchIntervalDefault 配置文件变更检测的轮询间隔的默认值。
+	prefsWatchIntervalDefault = 1000
+)
+
+var (
+	// changeMu 用于保护变更订阅列表的互斥锁。
+	changeMu sync.Mutex
+
+	// changeHooks 配置项变更的订阅列表。
+	changeHooks []*changeHook
+
+	// reloadHooks 配置文件重新读取且存在变更时的回调列表。
+	reloadHooks []*func()
+)
+
+// changeHook 定义了配置项变更的订阅。
+type changeHook struct {
+	prefix string             // 订阅的键名前缀
+	fn     func(old, new any) // 变更时的回调函数
+}
+
+// change 定义了一个配置项的变更。
+type change struct {
+	key string // 配置项的键名
+	old any    // 变更前的值，不存在时为 nil
+	new any    // 变更后的值，不存在时为 nil
+}
+
+// fileWatch 记录配置文件的路径及最近一次读取时的状态，用于检测文件的变更。
+type fileWatch struct {
+	mu   sync.Mutex // 互斥锁，用于保护文件状态
+	file string     // 配置文件路径
+	mod  time.Time  // 最近一次读取时的修改时间
+	size int64      // 最近一次读取时的文件大小
+}
+
+// OnChange 订阅配置项的变更。
+// keyPrefix 为订阅的键名前缀，为空时订阅所有配置项；fn 为变更时的回调函数，old 和 new 分别为变更前后的值，不存在时为 nil。
+// 资产配置和本地配置的文件变更后会重新读取，每个发生变更的配置项均会触发一次回调。
+// 返回取消订阅的函数。
+func OnChange(keyPrefix string, fn func(old, new any)) func() {
+	if fn == nil {
+		fmt.Printf("XPrefs.OnChange: nil function.\n")
+		return func() {}
+	}
+	hook := &changeHook{prefix: keyPrefix, fn: fn}
+
+	changeMu.Lock()
+	changeHooks = append(changeHooks, hook)
+	changeMu.Unlock()
+
+	return func() {
+		changeMu.Lock()
+		defer changeMu.Unlock()
+		for i, h := range changeHooks {
+			if h == hook {
+				changeHooks = append(changeHooks[:i:i], changeHooks[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// onReload 注册配置文件重新读取且存在变更时的回调，每次重新读取仅回调一次。
+// 返回取消注册的函数。
+func onReload(fn func()) func() {
+	hook := &fn
+
+	changeMu.Lock()
+	reloadHooks = append(reloadHooks, hook)
+	changeMu.Unlock()
+
+	return func() {
+		changeMu.Lock()
+		defer changeMu.Unlock()
+		for i, h := range reloadHooks {
+			if h == hook {
+				reloadHooks = append(reloadHooks[:i:i], reloadHooks[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// notify 通知配置项变更的订阅者，回调函数发生异常时不影响其他订阅者。
+func notify(source string, changes []change) {
+	if len(changes) == 0 {
+		return
+	}
+	changeMu.Lock()
+	hooks, rhooks := changeHooks, reloadHooks
+	changeMu.Unlock()
+
+	for _, c := range changes {
+		fmt.Printf("XPrefs.%v.Reload: key %v changed.\n", source, c.key)
+		for _, hook := range hooks {
+			if strings.HasPrefix(c.key, hook.prefix) {
+				func() {
+					defer func() {
+						if err := recover(); err != nil {
+							fmt.Printf("XPrefs.OnChange: callback of %v panic: %v\n%s", c.key, err, debug.Stack())
+						}
+					}()
+					hook.fn(c.old, c.new)
+				}()
+			}
+		}
+	}
+	for _, hook := range rhooks {
+		func() {
+			defer func() {
+				if err := recover(); err != nil {
+					fmt.Printf("XPrefs.%v.Reload: callback panic: %v\n%s", source, err, debug.Stack())
+				}
+			}()
+			(*hook)()
+		}()
+	}
+}
+
+// swap 原子地替换所有配置项，读取方不会观察到部分替换的配置。
+// 返回按键名排序的变更列表。
+func (pb *prefsBase) swap(pairs map[string]any) []change {
+	pb.Lock()
+	defer pb.Unlock()
+
+	var changes []change
+	for key, old := range pb.pairs {
+		nv, exists := pairs[key]
+		if !exists || !reflect.DeepEqual(old, nv) {
+			changes = append(changes, change{key: key, old: old, new: nv})
+		}
+	}
+	for key, nv := range pairs {
+		if _, exists := pb.pairs[key]; !exists {
+			changes = append(changes, change{key: key, new: nv})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].key < changes[j].key })
+
+	pb.pairs = pairs
+	pb.npairs = nil
+	pb.keys = make([]string, 0, len(pairs))
+	for key := range pairs {
+		pb.keys = append(pb.keys, key)
+	}
+	return changes
+}
+
+// mark 记录配置文件的当前状态。
+func (fw *fileWatch) mark(file string) {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+
+	fw.file = file
+	fw.mod, fw.size = time.Time{}, 0
+	if info, err := os.Stat(file); err == nil {
+		fw.mod, fw.size = info.ModTime(), info.Size()
+	}
+}
+
+// watchFiles 记录多个配置文件的当前状态，不存在的文件在创建后视为发生变更。
+func watchFiles(files []string) []*fileWatch {
+	watches := make([]*fileWatch, len(files))
+	for i, file := range files {
+		watches[i] = &fileWatch{}
+		watches[i].mark(file)
+	}
+	return watches
+}
+
+// changed 检查配置文件是否发生变更，变更时记录文件的当前状态。
+// 返回配置文件路径及是否发生变更，文件不存在时视为未变更。
+func (fw *fileWatch) changed() (string, bool) {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+
+	if fw.file == "" {
+		return "", false
+	}
+	info, err := os.Stat(fw.file)
+	if err != nil || info.IsDir() {
+		return fw.file, false
+	}
+	if info.ModTime().Equal(fw.mod) && info.Size() == fw.size {
+		return fw.file, false
+	}
+	fw.mod, fw.size = info.ModTime(), info.Size()
+	return fw.file, true
+}
+
+// reload 函数在资产配置文件或叠加配置文件变更后重新读取偏好设置。
+// 解析或校验失败时保留原有的配置，否则原子地替换配置项并通知订阅者。
+// 如果重新读取成功，则返回 true，否则返回 false。
+func (pa *prefsAsset) reload() bool {
+	file, ok := pa.watch.changed()
+	for _, profile := range pa.profiles {
+		if _, changed := profile.changed(); changed {
+			ok = true
+		}
+	}
+	if !ok || file == "" {
+		return false
+	}
+	data, err := readFile(file)
+	if err != nil {
+		fmt.Printf("XPrefs.Asset.Reload: failed to read file %s: %v\n", file, err)
+		return false
+	}
+	if data, err = decode(file, data); err != nil {
+		fmt.Printf("XPrefs.Asset.Reload: reject invalid file %s: %v\n", file, err)
+		return false
+	}
+	data, origins, files, err := layer(file, data, pa.ovr)
+	if err != nil {
+		fmt.Printf("XPrefs.Asset.Reload: reject invalid profile of %s: %v\n", file, err)
+		return false
+	}
+	fresh := &prefsAsset{}
+	fresh.origins = origins
+	fresh.ovr = pa.ovr
+	if !fresh.parse(data) {
+		fmt.Printf("XPrefs.Asset.Reload: reject invalid file %s, keep previous preferences.\n", file)
+		return false
+	}
+	if errs := validate(fresh); len(errs) > 0 {
+		report("Asset", errs)
+		fmt.Printf("XPrefs.Asset.Reload: reject file %s which failed validation, keep previous preferences.\n", file)
+		return false
+	}
+	pa.profiles = watchFiles(files)
+	changes := pa.swap(fresh.pairs)
+	pa.Lock()
+	pa.origins = fresh.origins
+	pa.Unlock()
+	notify("Asset", changes)
+	return true
+}
+
+// reload 函数在本地配置文件变更后重新读取偏好设置。
+// 解析或校验失败时保留原有的配置，否则原子地替换配置项并通知订阅者。
+// 未保存的修改将被文件中的内容覆盖，通过 Save 写入的变更不会触发重新读取。
+// 如果重新读取成功，则返回 true，否则返回 false。
+func (pl *prefsLocal) reload() bool {
+	file, ok := pl.watch.changed()
+	if !ok {
+		return false
+	}
+	data, err := readFile(file)
+	if err != nil {
+		fmt.Printf("XPrefs.Local.Reload: failed to read file %s: %v\n", file, err)
+		return false
+	}
+	if data, err = decode(file, data); err != nil {
+		fmt.Printf("XPrefs.Local.Reload: reject invalid file %s: %v\n", file, err)
+		return false
+	}
+	fresh := &prefsLocal{}
+	fresh.ovr = pl.ovr
+	if !fresh.parse(data) {
+		fmt.Printf("XPrefs.Local.Reload: reject invalid file %s, keep previous preferences.\n", file)
+		return false
+	}
+	if errs := validate(fresh); len(errs) > 0 {
+		report("Local", errs)
+		fmt.Printf("XPrefs.Local.Reload: reject file %s which failed validation, keep previous preferences.\n", file)
+		return false
+	}
+	notify("Local", pl.swap(fresh.pairs))
+	return true
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2025 EFramework Organization. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package XPrefs
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatch(t *testing.T) {
+	originalArgs := os.Args
+	defer func() {
+		os.Args = originalArgs
+		reset()
+	}()
+
+	tmpDir := t.TempDir()
+	assetFile := filepath.Join(tmpDir, "Assets/Preferences.json")
+	localFile := filepath.Join(tmpDir, "Local/Preferences.json")
+	assert.NoError(t, writeFile(assetFile, []byte(`{"Prefs/Watch/Interval": 10, "name": "Asset", "level": 1}`)))
+	assert.NoError(t, writeFile(localFile, []byte(`{"name": "Local"}`)))
+
+	reset()
+	os.Args = []string{"test", "--Prefs@Asset=" + assetFile, "--Prefs@Local=" + localFile}
+
+	var mu sync.Mutex
+	var changes [][2]any
+	unsub := OnChange("name", func(old, new any) {
+		mu.Lock()
+		defer mu.Unlock()
+		changes = append(changes, [2]any{old, new})
+	})
+	defer unsub()
+	received := func() [][2]any {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([][2]any{}, changes...)
+	}
+	forget := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		changes = nil
+	}
+
+	t.Run("Asset", func(t *testing.T) {
+		assert.Equal(t, "Asset", Asset().GetString("name"))
+		forget()
+		assert.NoError(t, writeFile(assetFile, []byte(`{"Prefs/Watch/Interval": 10, "name": "AssetChanged", "level": 1}`)))
+		assert.Eventually(t, func() bool { return Asset().GetString("name") == "AssetChanged" }, time.Second, time.Millisecond*10,
+			"资产配置文件变更后应当重新读取")
+		assert.Eventually(t, func() bool { return len(received()) == 1 }, time.Second, time.Millisecond*10)
+		assert.Equal(t, [2]any{"Asset", "AssetChanged"}, received()[0], "回调应当传入变更前后的值")
+		assert.Equal(t, 1, Asset().GetInt("level"), "未变更的配置项应当保留")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		forget()
+		assert.NoError(t, writeFile(assetFile, []byte(`{"name": "Broken",`)))
+		time.Sleep(time.Millisecond * 100)
+		assert.Equal(t, "AssetChanged", Asset().GetString("name"), "无效的配置文件应当被拒绝并保留原有的配置")
+		assert.Empty(t, received())
+
+		assert.NoError(t, writeFile(assetFile, []byte(`{"Prefs/Watch/Interval": 10, "name": "AssetFixed"}`)))
+		assert.Eventually(t, func() bool { return Asset().GetString("name") == "AssetFixed" }, time.Second, time.Millisecond*10,
+			"修复后的配置文件应当被重新读取")
+		assert.False(t, Asset().Has("level"), "移除的配置项应当被删除")
+	})
+
+	t.Run("Local", func(t *testing.T) {
+		assert.Equal(t, "Local", Local().GetString("name"))
+		forget()
+		assert.NoError(t, writeFile(localFile, []byte(`{"name": "LocalChanged", "other": true}`)))
+		assert.Eventually(t, func() bool { return Local().GetString("name") == "LocalChanged" }, time.Second, time.Millisecond*10,
+			"本地配置文件变更后应当重新读取")
+		assert.Eventually(t, func() bool { return len(received()) == 1 }, time.Second, time.Millisecond*10,
+			"仅订阅前缀匹配的配置项应当触发回调")
+		assert.Equal(t, [2]any{"Local", "LocalChanged"}, received()[0])
+	})
+
+	t.Run("Save", func(t *testing.T) {
+		forget()
+		Local().Set("name", "Saved")
+		assert.True(t, Local().Save())
+		Local().Set("unsaved", true)
+		time.Sleep(time.Millisecond * 100)
+		assert.True(t, Local().GetBool("unsaved"), "自身的写入不应当触发重新读取")
+		assert.Empty(t, received())
+	})
+
+	t.Run("Unsubscribe", func(t *testing.T) {
+		forget()
+		unsub()
+		assert.NoError(t, writeFile(assetFile, []byte(`{"Prefs/Watch/Interval": 10, "name": "AssetUnsubscribed"}`)))
+		assert.Eventually(t, func() bool { return Asset().GetString("name") == "AssetUnsubscribed" }, time.Second, time.Millisecond*10)
+		assert.Empty(t, received(), "取消订阅后不应当触发回调")
+	})
+}
+
+func TestSwap(t *testing.T) {
+	pb := &prefsBase{}
+	pb.Set("a", 1).Set("b", map[string]any{"c": 2})
+	assert.NotNil(t, pb.Get("b"))
+
+	changes := pb.swap(map[string]any{"b": map[string]any{"c": 3}, "d": "x"})
+	assert.Equal(t, []change{
+		{key: "a", old: 1},
+		{key: "b", old: map[string]any{"c": 2}, new: map[string]any{"c": 3}},
+		{key: "d", new: "x"},
+	}, changes)
+	assert.ElementsMatch(t, []string{"b", "d"}, pb.Keys())
+	assert.Equal(t, 3, pb.Get("b").(IBase).GetInt("c"), "替换后不应当读取到缓存的多级配置")
+}
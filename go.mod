@@ -3,11 +3,13 @@ module github.com/eframework-org/GO.UTIL
 go 1.23
 
 require (
+	github.com/BurntSushi/toml v1.5.0
 	github.com/illumitacit/gostd v0.7.1
 	github.com/petermattis/goid v0.0.0-20250813065127-a731cc31b4fe
 	github.com/prometheus/client_golang v1.22.0
 	github.com/shiena/ansicolor v0.0.0-20230509054315-a9deabde6e02
 	github.com/stretchr/testify v1.10.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -23,5 +25,4 @@ require (
 	github.com/prometheus/procfs v0.15.1 // indirect
 	golang.org/x/sys v0.30.0 // indirect
 	google.golang.org/protobuf v1.36.5 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )